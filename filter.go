@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/korve/go-http-feeds/pkg"
+)
+
+// stringsFlag is a flag that can be repeated to collect several values.
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// dataFilter matches the value at a dot-separated path in the event data against a glob pattern.
+type dataFilter struct {
+	path    string
+	pattern string
+}
+
+// eventFilter selects the events printed by the CLI. Patterns use glob syntax, e.g. "order.*".
+// An event matches if its type matches any of the type patterns, its subject matches any of the subject patterns
+// and all data filters match. Empty pattern lists match every event.
+type eventFilter struct {
+	types    []string
+	subjects []string
+	data     []dataFilter
+}
+
+// newEventFilter creates an eventFilter from the values of the CLI flags. Data filters have the form key=pattern.
+func newEventFilter(types, subjects, data []string) (*eventFilter, error) {
+	f := &eventFilter{
+		types:    types,
+		subjects: subjects,
+	}
+
+	for _, pattern := range append(append([]string{}, types...), subjects...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+
+	for _, d := range data {
+		key, pattern, ok := strings.Cut(d, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid filter %q, expected key=value", d)
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+
+		f.data = append(f.data, dataFilter{path: key, pattern: pattern})
+	}
+
+	return f, nil
+}
+
+func (f *eventFilter) match(e pkg.Event) bool {
+	if !matchAny(f.types, e.Type) || !matchAny(f.subjects, e.Subject) {
+		return false
+	}
+
+	for _, d := range f.data {
+		v, ok := lookupPath(e.Data, d.path)
+		if !ok {
+			return false
+		}
+		if matched, _ := path.Match(d.pattern, fmt.Sprint(v)); !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchAny reports whether value matches any of the glob patterns. No patterns match every value.
+func matchAny(patterns []string, value string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, value); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// lookupPath returns the value at a dot-separated path like "order.id" in data.
+func lookupPath(data interface{}, p string) (interface{}, bool) {
+	var v interface{} = data
+	for _, key := range strings.Split(p, ".") {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+
+		v, ok = m[key]
+		if !ok {
+			return nil, false
+		}
+	}
+
+	return v, true
+}
@@ -8,13 +8,23 @@ import (
 	"github.com/korve/go-http-feeds/pkg"
 	"net/url"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 )
 
 var pollDelay int
 var timeout int
 var lastEventId string
+var from string
 var verbose bool
+var output string
+var outputTemplate string
+var types stringsFlag
+var subjects stringsFlag
+var dataFilters stringsFlag
+var count bool
+var field string
 
 func printUsage() {
 	fmt.Printf("Usage: %s [options] <endpoint>\n", os.Args[0])
@@ -28,7 +38,15 @@ func main() {
 	flag.IntVar(&pollDelay, "poll-delay", 5000, "Poll delay in milliseconds between each poll to the HTTP endpoint")
 	flag.IntVar(&timeout, "timeout", 0, "timeout in milliseconds until the server must send a response")
 	flag.StringVar(&lastEventId, "last-event-id", "", "Last event ID received by the client")
+	flag.StringVar(&from, "from", "start", "Where to start the subscription: start (the beginning of the feed or -last-event-id), now (only new events) or an event ID")
 	flag.BoolVar(&verbose, "verbose", false, "Verbose output")
+	flag.StringVar(&output, "output", "text", "Output format of the events: text, ndjson or pretty")
+	flag.StringVar(&field, "field", "", "Only print the value at this dot-path into the event data, e.g. 'order.id', in the -output format. Events without the value print an empty line, or null in the JSON formats. Unset prints the whole event")
+	flag.StringVar(&outputTemplate, "template", "", "Go template used to format each event, e.g. '{{.ID}} {{.Type}}'. Overrides -output")
+	flag.Var(&types, "type", "Only print events with a matching type. Supports glob patterns like 'order.*'. Can be repeated")
+	flag.Var(&subjects, "subject", "Only print events with a matching subject. Supports glob patterns. Can be repeated")
+	flag.Var(&dataFilters, "filter", "Only print events whose data matches key=pattern, e.g. 'order.status=paid'. Can be repeated")
+	flag.BoolVar(&count, "count", false, "Print the number of matching events after -last-event-id and the last event ID of the feed, then exit")
 	flag.Parse()
 
 	endpoint := flag.Arg(0)
@@ -38,10 +56,33 @@ func main() {
 	}
 
 	if _, err := url.Parse(endpoint); err != nil {
-		panic("endpoint must be a valid URL")
+		fmt.Printf("endpoint must be a valid URL: %v\n", err)
+		os.Exit(1)
 	}
 
 	var err error
+	lastEventId, err = startEventId(from, lastEventId)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		os.Exit(1)
+	}
+	if count && lastEventId == pkg.SubscribeFromNow {
+		fmt.Printf("-from now can't be used with -count\n")
+		os.Exit(1)
+	}
+
+	printEvent, err := newPrinter(output, outputTemplate, field)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		os.Exit(1)
+	}
+
+	filter, err := newEventFilter(types, subjects, dataFilters)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		os.Exit(1)
+	}
+
 	var pollDelayDuration time.Duration
 	var timeoutDuration time.Duration
 
@@ -68,32 +109,125 @@ func main() {
 		fmt.Printf("lastEventId: %s\n", lastEventId)
 	}
 
-	events := make(chan pkg.Event)
-	ctx := context.Background()
+	// Counting stops at the end of the feed, there is no need to wait for new events
+	if count {
+		timeoutDuration = 0
+	}
 
-	go func() {
-		client := pkg.NewClient(pkg.ClientOptions{
-			PollDelay: pollDelayDuration,
-			Timeout:   timeoutDuration,
-		})
-		err = client.Subscribe(endpoint, lastEventId, events, ctx)
+	client, err := pkg.NewClientWithError(pkg.ClientOptions{
+		PollDelay: pollDelayDuration,
+		Timeout:   timeoutDuration,
+	})
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		os.Exit(1)
+	}
+
+	// SIGINT and SIGTERM cancel the subscription
+	signalCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if count {
+		n, lastFeedEventId, err := countEvents(client, endpoint, lastEventId, filter, signalCtx)
 		if err != nil {
-			fmt.Printf("error: %v\n", err)
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
 			os.Exit(1)
 		}
+
+		fmt.Printf("count: %d\n", n)
+		fmt.Printf("lastEventId: %s\n", lastFeedEventId)
+		return
+	}
+
+	events := make(chan pkg.Event)
+
+	ctx, cancel := context.WithCancelCause(signalCtx)
+	defer cancel(nil)
+
+	// The subscription ends the main loop by cancelling the context with its error
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		cancel(client.Subscribe(endpoint, lastEventId, events, ctx))
 	}()
 
+	// Starting from now, the position is unknown until the first event has been received
+	lastReceivedEventId := lastEventId
+	if lastReceivedEventId == pkg.SubscribeFromNow {
+		lastReceivedEventId = ""
+	}
 	for {
 		select {
-		case <-ctx.Done():
+		case <-done:
+			if lastReceivedEventId != "" {
+				fmt.Fprintf(os.Stderr, "lastEventId: %s\n", lastReceivedEventId)
+			}
+
 			cause := context.Cause(ctx)
 			if cause != nil && !errors.Is(cause, context.Canceled) {
-				panic(cause)
+				fmt.Fprintf(os.Stderr, "error: %v\n", cause)
+				os.Exit(1)
 			}
 			return
 
 		case e := <-events:
-			fmt.Printf("%+v\n", e)
+			// Discard events still in flight while the subscription is shutting down
+			if ctx.Err() != nil {
+				continue
+			}
+
+			lastReceivedEventId = e.ID
+			if !filter.match(e) {
+				continue
+			}
+
+			if err := printEvent(os.Stdout, e); err != nil {
+				fmt.Fprintf(os.Stderr, "error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+	}
+}
+
+// startEventId returns the lastEventId to subscribe with for the -from flag. Starting at an event ID or now is
+// ambiguous with a -last-event-id.
+func startEventId(from, lastEventId string) (string, error) {
+	switch from {
+	case "start":
+		return lastEventId, nil
+	case "":
+		return "", errors.New("-from must be start, now or an event ID")
+	}
+
+	if lastEventId != "" {
+		return "", fmt.Errorf("-from %s can't be combined with -last-event-id", from)
+	}
+	if from == "now" {
+		return pkg.SubscribeFromNow, nil
+	}
+
+	return from, nil
+}
+
+// countEvents fetches the events after lastEventId up to the end of the feed and counts the ones matching filter.
+// Returns the count and the ID of the last event of the feed.
+func countEvents(client *pkg.Client, endpoint, lastEventId string, filter *eventFilter, ctx context.Context) (int, string, error) {
+	n := 0
+	for {
+		events, next, err := client.Fetch(endpoint, lastEventId, ctx)
+		if err != nil {
+			return n, lastEventId, err
+		}
+
+		for _, e := range events {
+			if filter.match(e) {
+				n++
+			}
+		}
+
+		if len(events) == 0 || next == lastEventId {
+			return n, lastEventId, nil
 		}
+		lastEventId = next
 	}
 }
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/template"
+
+	"github.com/korve/go-http-feeds/pkg"
+)
+
+// printer writes a single event to the output.
+type printer func(w io.Writer, e pkg.Event) error
+
+// newPrinter creates the printer for the given output format. A non-empty tmpl takes precedence over the format.
+// A non-empty field prints only the value at that dot-path into the data of each event, in the given format.
+func newPrinter(format string, tmpl string, field string) (printer, error) {
+	if tmpl != "" {
+		t, err := template.New("event").Parse(tmpl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template: %w", err)
+		}
+
+		return func(w io.Writer, e pkg.Event) error {
+			if err := t.Execute(w, e); err != nil {
+				return err
+			}
+			_, err := fmt.Fprintln(w)
+			return err
+		}, nil
+	}
+
+	if field != "" {
+		return newFieldPrinter(format, field)
+	}
+
+	switch format {
+	case "text":
+		return func(w io.Writer, e pkg.Event) error {
+			_, err := fmt.Fprintf(w, "%+v\n", e)
+			return err
+		}, nil
+
+	case "ndjson":
+		return func(w io.Writer, e pkg.Event) error {
+			return json.NewEncoder(w).Encode(e)
+		}, nil
+
+	case "pretty":
+		return func(w io.Writer, e pkg.Event) error {
+			encoder := json.NewEncoder(w)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(e)
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown output format: %s", format)
+	}
+}
+
+// newFieldPrinter creates a printer for the value at the dot-path field into the data of each event. Events without
+// the field print an empty line, or null in the JSON formats.
+func newFieldPrinter(format string, field string) (printer, error) {
+	value := func(e pkg.Event) interface{} {
+		v, _ := lookupPath(e.Data, field)
+		return v
+	}
+
+	switch format {
+	case "text":
+		return func(w io.Writer, e pkg.Event) error {
+			v := value(e)
+			if v == nil {
+				_, err := fmt.Fprintln(w)
+				return err
+			}
+			_, err := fmt.Fprintln(w, v)
+			return err
+		}, nil
+
+	case "ndjson":
+		return func(w io.Writer, e pkg.Event) error {
+			return json.NewEncoder(w).Encode(value(e))
+		}, nil
+
+	case "pretty":
+		return func(w io.Writer, e pkg.Event) error {
+			encoder := json.NewEncoder(w)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(value(e))
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown output format: %s", format)
+	}
+}
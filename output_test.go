@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/korve/go-http-feeds/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookupPath(t *testing.T) {
+	data := map[string]interface{}{
+		"order": map[string]interface{}{"id": "42", "total": 9.5},
+		"sku":   "abc",
+	}
+
+	tests := map[string]struct {
+		path     string
+		expected interface{}
+		ok       bool
+	}{
+		"top-level":          {path: "sku", expected: "abc", ok: true},
+		"nested":             {path: "order.id", expected: "42", ok: true},
+		"object":             {path: "order", expected: data["order"], ok: true},
+		"missing":            {path: "customer"},
+		"missing nested":     {path: "order.customer"},
+		"path into a scalar": {path: "sku.id"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			v, ok := lookupPath(data, test.path)
+			assert.Equal(t, test.ok, ok)
+			assert.Equal(t, test.expected, v)
+		})
+	}
+
+	_, ok := lookupPath(nil, "sku")
+	assert.False(t, ok)
+}
+
+func TestNewFieldPrinter(t *testing.T) {
+	events := []pkg.Event{
+		{ID: "1", Data: map[string]interface{}{"order": map[string]interface{}{"id": "42"}}},
+		{ID: "2", Data: map[string]interface{}{"sku": "abc"}},
+	}
+
+	tests := map[string]string{
+		"text":   "42\n\n",
+		"ndjson": "\"42\"\nnull\n",
+		"pretty": "\"42\"\nnull\n",
+	}
+	for format, expected := range tests {
+		t.Run(format, func(t *testing.T) {
+			printEvent, err := newPrinter(format, "", "order.id")
+			assert.NoError(t, err)
+
+			var buf bytes.Buffer
+			for _, e := range events {
+				assert.NoError(t, printEvent(&buf, e))
+			}
+			assert.Equal(t, expected, buf.String())
+		})
+	}
+
+	_, err := newFieldPrinter("xml", "order.id")
+	assert.Error(t, err)
+}
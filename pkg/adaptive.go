@@ -0,0 +1,77 @@
+package pkg
+
+import "time"
+
+const DefaultAdaptiveMinDelay = 100 * time.Millisecond
+const DefaultAdaptiveGrowthFactor = 2.0
+const DefaultAdaptiveShrinkFactor = 0.5
+
+// AdaptivePollingOptions configures a poll delay that adapts to the activity of the feed. Only used for simple
+// polling, as long-polling servers respond as soon as there are new events anyway.
+type AdaptivePollingOptions struct {
+	// MinDelay is the shortest delay between polls of an active feed. Defaults to 100 milliseconds.
+	MinDelay time.Duration
+
+	// MaxDelay is the longest delay between polls of an idle feed. Adaptive polling is disabled when zero.
+	MaxDelay time.Duration
+
+	// GrowthFactor is the factor the delay grows by after an empty poll. Defaults to 2.
+	GrowthFactor float64
+
+	// ShrinkFactor is the factor the delay shrinks by after a full batch. Defaults to 0.5.
+	ShrinkFactor float64
+
+	// FullBatchSize is the number of events a poll must return to be considered a full batch. Polls returning
+	// fewer events keep the current delay. Defaults to 1, i.e. every non-empty poll shrinks the delay.
+	FullBatchSize int
+}
+
+// adaptiveDelay keeps track of the poll delay of a single subscription with adaptive polling.
+type adaptiveDelay struct {
+	opts  AdaptivePollingOptions
+	delay time.Duration
+}
+
+func newAdaptiveDelay(opts AdaptivePollingOptions, pollDelay time.Duration) *adaptiveDelay {
+	if opts.MinDelay <= 0 {
+		opts.MinDelay = DefaultAdaptiveMinDelay
+	}
+	if opts.MinDelay > opts.MaxDelay {
+		opts.MinDelay = opts.MaxDelay
+	}
+	if opts.GrowthFactor <= 1 {
+		opts.GrowthFactor = DefaultAdaptiveGrowthFactor
+	}
+	if opts.ShrinkFactor <= 0 || opts.ShrinkFactor >= 1 {
+		opts.ShrinkFactor = DefaultAdaptiveShrinkFactor
+	}
+	if opts.FullBatchSize <= 0 {
+		opts.FullBatchSize = 1
+	}
+
+	a := &adaptiveDelay{opts: opts, delay: pollDelay}
+	a.clamp()
+
+	return a
+}
+
+// next returns the delay until the next poll after a poll that returned n events.
+func (a *adaptiveDelay) next(n int) time.Duration {
+	if n == 0 {
+		a.delay = time.Duration(float64(a.delay) * a.opts.GrowthFactor)
+	} else if n >= a.opts.FullBatchSize {
+		a.delay = time.Duration(float64(a.delay) * a.opts.ShrinkFactor)
+	}
+	a.clamp()
+
+	return a.delay
+}
+
+func (a *adaptiveDelay) clamp() {
+	if a.delay < a.opts.MinDelay {
+		a.delay = a.opts.MinDelay
+	}
+	if a.delay > a.opts.MaxDelay {
+		a.delay = a.opts.MaxDelay
+	}
+}
@@ -0,0 +1,37 @@
+package pkg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdaptiveDelay_next(t *testing.T) {
+	a := newAdaptiveDelay(AdaptivePollingOptions{
+		MinDelay: 100 * time.Millisecond,
+		MaxDelay: 1 * time.Second,
+	}, 400*time.Millisecond)
+
+	// 1. Empty polls grow the delay up to the max
+	assert.Equal(t, 800*time.Millisecond, a.next(0))
+	assert.Equal(t, 1*time.Second, a.next(0))
+
+	// 2. Non-empty polls shrink the delay down to the min
+	assert.Equal(t, 500*time.Millisecond, a.next(3))
+	assert.Equal(t, 250*time.Millisecond, a.next(1))
+	assert.Equal(t, 125*time.Millisecond, a.next(1))
+	assert.Equal(t, 100*time.Millisecond, a.next(1))
+}
+
+func TestAdaptiveDelay_next_FullBatchSize(t *testing.T) {
+	a := newAdaptiveDelay(AdaptivePollingOptions{
+		MinDelay:      100 * time.Millisecond,
+		MaxDelay:      1 * time.Second,
+		FullBatchSize: 10,
+	}, 400*time.Millisecond)
+
+	// Partial batches keep the delay
+	assert.Equal(t, 400*time.Millisecond, a.next(5))
+	assert.Equal(t, 200*time.Millisecond, a.next(10))
+}
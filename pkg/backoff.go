@@ -0,0 +1,91 @@
+package pkg
+
+import (
+	"math/rand"
+	"time"
+)
+
+const DefaultBackoffMultiplier = 2.0
+
+// BackoffOptions configures the exponential backoff applied to the poll delay after failed requests.
+type BackoffOptions struct {
+	// InitialDelay is the delay before the first retry after a failed poll. Backoff is disabled when zero,
+	// in which case failed polls are retried after the regular poll delay.
+	InitialDelay time.Duration
+
+	// MaxDelay caps the delay between retries. Zero means no cap.
+	MaxDelay time.Duration
+
+	// Multiplier is the factor the delay grows by after each consecutive failure. Defaults to 2.
+	Multiplier float64
+
+	// Jitter randomizes each delay by up to the given fraction (0 to 1) in either direction,
+	// so that many clients failing at the same time don't retry in lockstep.
+	Jitter float64
+}
+
+// backoff keeps track of the consecutive failures of a single subscription.
+type backoff struct {
+	opts      BackoffOptions
+	pollDelay time.Duration
+	delay     time.Duration
+}
+
+func newBackoff(opts BackoffOptions, pollDelay time.Duration) *backoff {
+	if opts.InitialDelay < 0 {
+		opts.InitialDelay = 0
+	}
+	if opts.MaxDelay < 0 {
+		opts.MaxDelay = 0
+	}
+	if opts.Multiplier <= 1 {
+		opts.Multiplier = DefaultBackoffMultiplier
+	}
+	if opts.Jitter < 0 {
+		opts.Jitter = 0
+	}
+	if opts.Jitter > 1 {
+		opts.Jitter = 1
+	}
+
+	return &backoff{
+		opts:      opts,
+		pollDelay: pollDelay,
+	}
+}
+
+// next returns the delay to wait before the next retry and grows the delay for the following one.
+func (b *backoff) next() time.Duration {
+	if b.opts.InitialDelay == 0 {
+		return b.pollDelay
+	}
+
+	if b.delay == 0 {
+		b.delay = b.opts.InitialDelay
+	} else {
+		b.delay = time.Duration(float64(b.delay) * b.opts.Multiplier)
+	}
+	if b.opts.MaxDelay > 0 && b.delay > b.opts.MaxDelay {
+		b.delay = b.opts.MaxDelay
+	}
+
+	delay := b.delay
+	if b.opts.Jitter > 0 {
+		delay = time.Duration(float64(delay) * (1 + b.opts.Jitter*(2*rand.Float64()-1)))
+	}
+	if delay <= 0 {
+		delay = b.delay
+	}
+
+	return delay
+}
+
+// active reports whether the backoff has grown since the last reset.
+func (b *backoff) active() bool {
+	return b.delay != 0
+}
+
+// reset is called after a successful poll.
+func (b *backoff) reset() {
+	b.delay = 0
+}
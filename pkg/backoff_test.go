@@ -0,0 +1,101 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoff_next(t *testing.T) {
+	b := newBackoff(BackoffOptions{
+		InitialDelay: 10 * time.Millisecond,
+		MaxDelay:     50 * time.Millisecond,
+	}, time.Second)
+
+	assert.Equal(t, 10*time.Millisecond, b.next())
+	assert.Equal(t, 20*time.Millisecond, b.next())
+	assert.Equal(t, 40*time.Millisecond, b.next())
+	assert.Equal(t, 50*time.Millisecond, b.next())
+	assert.True(t, b.active())
+
+	b.reset()
+	assert.False(t, b.active())
+	assert.Equal(t, 10*time.Millisecond, b.next())
+}
+
+func TestBackoff_next_disabled(t *testing.T) {
+	b := newBackoff(BackoffOptions{}, time.Second)
+
+	assert.Equal(t, time.Second, b.next())
+	assert.Equal(t, time.Second, b.next())
+	assert.False(t, b.active())
+}
+
+func TestBackoff_next_jitter(t *testing.T) {
+	b := newBackoff(BackoffOptions{
+		InitialDelay: 100 * time.Millisecond,
+		Multiplier:   1.5,
+		Jitter:       0.5,
+	}, time.Second)
+
+	d := b.next()
+	assert.GreaterOrEqual(t, d, 50*time.Millisecond)
+	assert.LessOrEqual(t, d, 150*time.Millisecond)
+
+	d = b.next()
+	assert.GreaterOrEqual(t, d, 75*time.Millisecond)
+	assert.LessOrEqual(t, d, 225*time.Millisecond)
+}
+
+func TestClient_Subscribe_RetryBackoff(t *testing.T) {
+	var mu sync.Mutex
+	var requests []time.Time
+
+	// 1. Setup a test server that fails the first three requests
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, time.Now())
+		n := len(requests)
+		mu.Unlock()
+
+		if n <= 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		fmt.Fprintln(w, `[{"id":"1"}]`)
+	}))
+	defer ts.Close()
+
+	events := make(chan Event)
+	client := NewClient(ClientOptions{
+		PollDelay: 10 * time.Millisecond,
+		RetryBackoff: BackoffOptions{
+			InitialDelay: 20 * time.Millisecond,
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	go func() {
+		_ = client.Subscribe(ts.URL, "", events, ctx)
+	}()
+
+	ev := <-events
+	assert.Equal(t, "1", ev.ID)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	// 2. Expect the delay between the retries to grow: 20ms, 40ms, 80ms
+	assert.GreaterOrEqual(t, requests[1].Sub(requests[0]), 20*time.Millisecond)
+	assert.GreaterOrEqual(t, requests[2].Sub(requests[1]), 40*time.Millisecond)
+	assert.GreaterOrEqual(t, requests[3].Sub(requests[2]), 80*time.Millisecond)
+}
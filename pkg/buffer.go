@@ -0,0 +1,110 @@
+package pkg
+
+import "context"
+
+// OverflowPolicy decides what happens to new events while the buffer of a subscription is full, see BufferOptions.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock waits until the consumer makes room in the buffer, pausing the polling meanwhile.
+	OverflowBlock OverflowPolicy = iota
+
+	// OverflowDropOldest drops the oldest buffered event to make room for the new one.
+	OverflowDropOldest
+
+	// OverflowDropNewest drops the new event.
+	OverflowDropNewest
+)
+
+// BufferOptions configures a buffer between the polling and the channel of Subscribe and SubscribeMany, which
+// decouples the polling from the speed of the consumer. Events count as delivered once they are buffered, so the
+// Cursor advances past buffered and dropped events. Only use dropping for feeds which may lose events, e.g. for
+// real-time dashboards.
+type BufferOptions struct {
+	// Size is the number of events which are buffered. Buffering is disabled when zero.
+	Size int
+
+	// Overflow decides what happens to new events while the buffer is full. Defaults to OverflowBlock.
+	Overflow OverflowPolicy
+}
+
+// BufferMetrics can additionally be implemented by Metrics to record the events dropped from the buffer, see
+// ClientOptions.Buffer. IncEventsDropped is called for every dropped event.
+type BufferMetrics interface {
+	IncEventsDropped(n int)
+}
+
+// sendBuffered calls subscribe with a function which sends the events to events, through a buffer if enabled. After
+// subscribe has returned, the buffered events are still sent to events until ctx is cancelled.
+func (c *Client) sendBuffered(events chan Event, subscribe func(send func(Event) error) error, ctx context.Context) error {
+	if c.buffer.Size <= 0 {
+		return subscribe(sendTo(events, ctx))
+	}
+
+	buf := make(chan Event, c.buffer.Size)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for e := range buf {
+			select {
+			case events <- e:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	err := subscribe(c.bufferTo(buf, ctx))
+	close(buf)
+	<-done
+
+	return err
+}
+
+// bufferTo returns a function which adds events to buf, applying the overflow policy when it is full.
+func (c *Client) bufferTo(buf chan Event, ctx context.Context) func(Event) error {
+	return func(e Event) error {
+		switch c.buffer.Overflow {
+		case OverflowDropNewest:
+			select {
+			case buf <- e:
+			default:
+				c.dropped(e)
+			}
+			return nil
+
+		case OverflowDropOldest:
+			for {
+				select {
+				case buf <- e:
+					return nil
+				default:
+				}
+
+				// The consumer may have made room in the meantime
+				select {
+				case oldest := <-buf:
+					c.dropped(oldest)
+				default:
+				}
+			}
+
+		default:
+			select {
+			case buf <- e:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+// dropped records an event dropped from the buffer.
+func (c *Client) dropped(e Event) {
+	c.logger.Debug("dropping event from full buffer", "endpoint", e.Endpoint, "id", e.ID)
+	c.stats.dropped(e.Endpoint)
+	if m, ok := c.metrics.(BufferMetrics); ok {
+		m.IncEventsDropped(1)
+	}
+}
@@ -0,0 +1,133 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type bufferMetrics struct {
+	noopMetrics
+	dropped atomic.Int32
+}
+
+func (m *bufferMetrics) IncEventsDropped(n int) {
+	m.dropped.Add(int32(n))
+}
+
+func TestClient_Subscribe_Buffer(t *testing.T) {
+	tests := map[string]struct {
+		overflow OverflowPolicy
+		pick     func(received []string) []string
+		expected []string
+	}{
+		"drop oldest": {
+			overflow: OverflowDropOldest,
+			pick:     func(received []string) []string { return received[len(received)-2:] },
+			expected: []string{"4", "5"},
+		},
+		"drop newest": {
+			overflow: OverflowDropNewest,
+			pick:     func(received []string) []string { return received[:2] },
+			expected: []string{"1", "2"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			// 1. Set up a test server with five events
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("lastEventId") == "" {
+					fmt.Fprintln(w, `[{"id":"1"},{"id":"2"},{"id":"3"},{"id":"4"},{"id":"5"}]`)
+					return
+				}
+				fmt.Fprintln(w, `[]`)
+			}))
+			defer ts.Close()
+
+			metrics := &bufferMetrics{}
+			client := NewClient(ClientOptions{
+				PollDelay: 10 * time.Millisecond,
+				Metrics:   metrics,
+				Buffer:    BufferOptions{Size: 2, Overflow: test.overflow},
+			})
+
+			ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+			defer cancel()
+
+			// 2. Don't read the events until the polling has moved past all of them, so that the buffer overflows
+			events := make(chan Event)
+			go func() {
+				_ = client.Subscribe(ts.URL, "", events, ctx)
+			}()
+
+			assert.Eventually(t, func() bool {
+				lastEventId, _ := client.LastEventId(ts.URL)
+				return lastEventId == "5"
+			}, time.Second, 5*time.Millisecond)
+
+			// 3. Expect the buffered events to be delivered, and the others to be counted as dropped. Besides the
+			// buffer, one event may be held by the goroutine forwarding to the channel.
+			var received []string
+		read:
+			for {
+				select {
+				case e := <-events:
+					received = append(received, e.ID)
+				case <-time.After(50 * time.Millisecond):
+					break read
+				}
+			}
+
+			stats := client.Stats()
+			assert.Equal(t, int64(5), stats.EventsDelivered)
+			assert.Equal(t, int64(5-len(received)), stats.EventsDropped)
+			assert.Equal(t, stats.EventsDropped, stats.Feeds[ts.URL].EventsDropped)
+			assert.Equal(t, int32(stats.EventsDropped), metrics.dropped.Load())
+			if assert.GreaterOrEqual(t, len(received), 2) {
+				assert.Equal(t, test.expected, test.pick(received))
+			}
+		})
+	}
+}
+
+func TestClient_Subscribe_BufferBlock(t *testing.T) {
+	// 1. Set up a test server with three events
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("lastEventId") == "" {
+			fmt.Fprintln(w, `[{"id":"1"},{"id":"2"},{"id":"3"}]`)
+			return
+		}
+		fmt.Fprintln(w, `[]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{
+		PollDelay: 10 * time.Millisecond,
+		Buffer:    BufferOptions{Size: 1},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	events := make(chan Event)
+	go func() {
+		_ = client.Subscribe(ts.URL, "", events, ctx)
+	}()
+
+	// 2. Expect the polling to wait for the consumer instead of dropping events
+	time.Sleep(50 * time.Millisecond)
+	lastEventId, _ := client.LastEventId(ts.URL)
+	assert.Equal(t, "2", lastEventId)
+
+	for _, id := range []string{"1", "2", "3"} {
+		assert.Equal(t, id, (<-events).ID)
+	}
+	assert.Equal(t, int64(0), client.Stats().EventsDropped)
+}
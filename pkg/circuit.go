@@ -0,0 +1,55 @@
+package pkg
+
+import "time"
+
+const DefaultCircuitBreakerCooldown = 30 * time.Second
+
+// CircuitBreakerOptions configures a circuit breaker, which pauses the polling of a feed that keeps failing.
+// After FailureThreshold consecutive failed polls the circuit opens and the feed is not polled until the Cooldown
+// has passed. Then a single poll probes the feed: if it succeeds the circuit closes and polling resumes as usual,
+// otherwise the circuit stays open for another Cooldown.
+type CircuitBreakerOptions struct {
+	// FailureThreshold is the number of consecutive failed polls which open the circuit. The circuit breaker is
+	// disabled when zero.
+	FailureThreshold int
+
+	// Cooldown is how long the circuit stays open before the feed is probed again. Defaults to 30 seconds.
+	Cooldown time.Duration
+}
+
+// circuitBreaker keeps track of the circuit of a single subscription.
+type circuitBreaker struct {
+	opts     CircuitBreakerOptions
+	failures int
+	open     bool
+}
+
+func newCircuitBreaker(opts CircuitBreakerOptions) *circuitBreaker {
+	if opts.Cooldown <= 0 {
+		opts.Cooldown = DefaultCircuitBreakerCooldown
+	}
+
+	return &circuitBreaker{opts: opts}
+}
+
+// failure records a failed poll and reports whether the circuit has just opened.
+func (b *circuitBreaker) failure() bool {
+	b.failures++
+	if b.open || b.failures < b.opts.FailureThreshold {
+		return false
+	}
+
+	b.open = true
+	return true
+}
+
+// success records a successful poll and reports whether the circuit has just closed.
+func (b *circuitBreaker) success() bool {
+	b.failures = 0
+	if !b.open {
+		return false
+	}
+
+	b.open = false
+	return true
+}
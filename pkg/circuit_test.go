@@ -0,0 +1,116 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	b := newCircuitBreaker(CircuitBreakerOptions{FailureThreshold: 2})
+	assert.Equal(t, DefaultCircuitBreakerCooldown, b.opts.Cooldown)
+
+	assert.False(t, b.failure())
+	assert.True(t, b.failure())
+	assert.False(t, b.failure(), "a failed probe keeps the circuit open")
+	assert.True(t, b.success())
+	assert.False(t, b.success())
+	assert.False(t, b.failure(), "the failures are counted again after closing")
+}
+
+type circuitMetrics struct {
+	noopMetrics
+	mu    sync.Mutex
+	state []bool
+}
+
+func (m *circuitMetrics) ObserveCircuitOpen(open bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.state = append(m.state, open)
+}
+
+func TestClient_Subscribe_CircuitBreaker(t *testing.T) {
+	var mu sync.Mutex
+	var requests []time.Time
+
+	// 1. Setup a long-polling test server that fails the first three requests
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, time.Now())
+		n := len(requests)
+		mu.Unlock()
+
+		if n <= 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if n == 4 {
+			fmt.Fprintln(w, `[{"id":"1"}]`)
+			return
+		}
+
+		fmt.Fprintln(w, `[]`)
+	}))
+	defer ts.Close()
+
+	metrics := &circuitMetrics{}
+	events := make(chan Event)
+	client := NewClient(ClientOptions{
+		PollDelay: 10 * time.Millisecond,
+		Timeout:   time.Second,
+		Metrics:   metrics,
+		CircuitBreaker: CircuitBreakerOptions{
+			FailureThreshold: 2,
+			Cooldown:         100 * time.Millisecond,
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	go func() {
+		_ = client.Subscribe(ts.URL, "", events, ctx)
+	}()
+
+	// 2. The circuit opens after the second failure, so the third request is a probe after the cooldown
+	time.Sleep(50 * time.Millisecond)
+	assert.True(t, client.Stats().Feeds[ts.URL].CircuitOpen)
+
+	ev := <-events
+	assert.Equal(t, "1", ev.ID)
+
+	mu.Lock()
+	assert.GreaterOrEqual(t, requests[2].Sub(requests[1]), 100*time.Millisecond)
+	assert.GreaterOrEqual(t, requests[3].Sub(requests[2]), 100*time.Millisecond)
+	mu.Unlock()
+
+	// 3. Expect the circuit to close after the successful probe
+	assert.Eventually(t, func() bool {
+		return !client.Stats().Feeds[ts.URL].CircuitOpen
+	}, time.Second, 10*time.Millisecond)
+
+	metrics.mu.Lock()
+	assert.Equal(t, []bool{true, false}, metrics.state)
+	metrics.mu.Unlock()
+
+	// 4. Expect the polling to resume at the regular poll delay instead of the cooldown
+	resumed := assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(requests) >= 8
+	}, time.Second, 10*time.Millisecond)
+	if !resumed {
+		return
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Less(t, requests[7].Sub(requests[3]), 300*time.Millisecond)
+}
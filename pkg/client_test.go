@@ -1,11 +1,30 @@
 package pkg
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -38,6 +57,35 @@ func TestClient_fetchEvents(t *testing.T) {
 	assert.Equal(t, "2", events[1].ID)
 }
 
+func TestClient_Fetch(t *testing.T) {
+	// 1. Set up a test server with two events
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("lastEventId") == "" {
+			fmt.Fprintln(w, `[{"id":"1"},{"id":"2"}]`)
+			return
+		}
+		fmt.Fprintln(w, `[]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Expect the events and the new cursor
+	events, lastEventId, err := client.Fetch(ts.URL, "", ctx)
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, "2", lastEventId)
+
+	// 3. Expect the cursor to stay unchanged without new events
+	events, lastEventId, err = client.Fetch(ts.URL, lastEventId, ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+	assert.Equal(t, "2", lastEventId)
+}
+
 func TestClient_fetchEvents_setLastEventIdQueryParameter(t *testing.T) {
 	// 1. Set up a test server
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -109,6 +157,32 @@ func TestClient_fetchEvents_requestTimeout(t *testing.T) {
 	assert.Truef(t, errors.Is(err, context.DeadlineExceeded), "expected error to be DeadlineExceeded, got %v", err)
 }
 
+func TestClient_fetchEvents_unresponsiveLongPoll(t *testing.T) {
+	// 1. Set up a test server which never responds to long-polling requests
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{
+		Timeout:        50 * time.Millisecond,
+		LongPollMargin: 50 * time.Millisecond,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Expect the request to be cancelled after the timeout plus the margin, without a RequestTimeout
+	start := time.Now()
+	_, err := client.fetchEvents(ts.URL, "", ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.NoError(t, ctx.Err())
+}
+
 func TestClient_Subscribe_SimplePolling(t *testing.T) {
 	var lastEventIdQueryValue string
 
@@ -233,3 +307,1994 @@ func TestClient_Subscribe_WithUpdate(t *testing.T) {
 	ev3 := <-events
 	assert.Equal(t, "3", ev3.ID)
 }
+
+func TestClient_fetchEvents_setAuthorizationHeader(t *testing.T) {
+	var authorization string
+
+	// 1. Set up a test server
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		fmt.Fprintln(w, `[{"id":"1"}]`)
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Test that the token is sent as a Bearer token
+	client := NewClient(ClientOptions{AuthToken: "secret"})
+	_, err := client.fetchEvents(ts.URL, "", ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer secret", authorization)
+
+	// 3. Test that no header is sent without a token
+	client = NewClient(ClientOptions{})
+	_, err = client.fetchEvents(ts.URL, "", ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "", authorization)
+}
+
+func TestClient_fetchEvents_URLCredentials(t *testing.T) {
+	// 1. Set up a test server expecting basic auth
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "user", user)
+		assert.Equal(t, "p@ss", password)
+		fmt.Fprintln(w, `[{"id":"1"}]`)
+	}))
+	defer ts.Close()
+
+	u, err := url.Parse(ts.URL)
+	assert.NoError(t, err)
+	u.User = url.UserPassword("user", "p@ss")
+
+	transport := &userinfoTransport{}
+	client := NewClient(ClientOptions{HTTPClient: &http.Client{Transport: transport}})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Expect the credentials to be sent in the header and stripped from the request URL
+	events, err := client.fetchEvents(u.String(), "", ctx)
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Nil(t, transport.user)
+}
+
+// userinfoTransport records the userinfo of the request URL.
+type userinfoTransport struct {
+	user *url.Userinfo
+}
+
+func (t *userinfoTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.user = req.URL.User
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+type countingTransport struct {
+	requests int
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests++
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestClient_fetchEvents_customHTTPClient(t *testing.T) {
+	// 1. Set up a test server
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `[{"id":"1"}]`)
+	}))
+	defer ts.Close()
+
+	transport := &countingTransport{}
+	client := NewClient(ClientOptions{
+		HTTPClient: &http.Client{Transport: transport},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Test that the request is sent through the injected client
+	events, err := client.fetchEvents(ts.URL, "", ctx)
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, 1, transport.requests)
+}
+
+func TestClient_Subscribe_ErrorHandler(t *testing.T) {
+	var requests int32
+
+	// 1. Setup a test server that fails the first request
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		fmt.Fprintln(w, `[{"id":"1"}]`)
+	}))
+	defer ts.Close()
+
+	errs := make(chan error, 10)
+	events := make(chan Event)
+	client := NewClient(ClientOptions{
+		PollDelay: 10 * time.Millisecond,
+		ErrorHandler: func(err error) {
+			errs <- err
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	go func() {
+		_ = client.Subscribe(ts.URL, "", events, ctx)
+	}()
+
+	// 2. Expect the error to be reported and the subscription to continue
+	err := <-errs
+	assert.ErrorContains(t, err, "500 Internal Server Error")
+
+	ev := <-events
+	assert.Equal(t, "1", ev.ID)
+}
+
+func TestClient_Subscribe_invalidEndpoint(t *testing.T) {
+	client := NewClient(ClientOptions{})
+
+	err := client.Subscribe("://invalid", "", make(chan Event), context.Background())
+	assert.Error(t, err)
+}
+
+func TestClient_Subscribe_ValidateEvents(t *testing.T) {
+	// 1. Setup a test server returning an invalid event followed by a valid one
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("lastEventId") != "" {
+			fmt.Fprintln(w, `[]`)
+			return
+		}
+
+		fmt.Fprintln(w, `[{"id":"1"},{"id":"2","specversion":"1.0","type":"t","source":"/s"}]`)
+	}))
+	defer ts.Close()
+
+	errs := make(chan error, 10)
+	events := make(chan Event)
+	client := NewClient(ClientOptions{
+		PollDelay:      10 * time.Millisecond,
+		ValidateEvents: true,
+		ErrorHandler: func(err error) {
+			errs <- err
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	go func() {
+		_ = client.Subscribe(ts.URL, "", events, ctx)
+	}()
+
+	// 2. Expect the invalid event to be reported and skipped
+	ev := <-events
+	assert.Equal(t, "2", ev.ID)
+
+	err := <-errs
+	assert.True(t, errors.Is(err, ErrInvalidEvent))
+}
+
+func TestClient_Subscribe_SkipInvalidEvents(t *testing.T) {
+	// 1. Setup a test server returning a malformed event between two valid ones
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("lastEventId") != "" {
+			fmt.Fprintln(w, `[]`)
+			return
+		}
+
+		fmt.Fprintln(w, `[{"id":"1"},{"id":2},{"id":"3"}]`)
+	}))
+	defer ts.Close()
+
+	errs := make(chan error, 10)
+	events := make(chan Event)
+	client := NewClient(ClientOptions{
+		PollDelay:         10 * time.Millisecond,
+		SkipInvalidEvents: true,
+		ErrorHandler: func(err error) {
+			errs <- err
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	go func() {
+		_ = client.Subscribe(ts.URL, "", events, ctx)
+	}()
+
+	// 2. Expect the valid events to be delivered and the malformed one to be reported
+	assert.Equal(t, "1", (<-events).ID)
+	assert.Equal(t, "3", (<-events).ID)
+
+	err := <-errs
+	var typeErr *json.UnmarshalTypeError
+	assert.True(t, errors.As(err, &typeErr))
+}
+
+func TestClient_fetchEvents_EmptyBody(t *testing.T) {
+	// 1. Set up a test server returning an empty body without a content type and a whitespace-only body
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("lastEventId") == "1" {
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, " \n")
+			return
+		}
+		w.Header()["Content-Type"] = nil
+	}))
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{AcceptMediaTypes: FeedMediaTypes})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Expect no events instead of an error
+	events, err := client.fetchEvents(ts.URL, "", ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+
+	events, err = client.fetchEvents(ts.URL, "1", ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func TestClient_fetchEvents_MalformedEvent(t *testing.T) {
+	// 1. Set up a test server returning a malformed event
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `[{"id":"1"},{"id":2}]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Without SkipInvalidEvents the whole response fails
+	_, err := client.fetchEvents(ts.URL, "", ctx)
+	assert.Error(t, err)
+}
+
+func TestClient_fetchEvents_ConditionalRequests(t *testing.T) {
+	var notModified int32
+
+	// 1. Set up a test server supporting ETags
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		etag := `"` + r.URL.Query().Get("lastEventId") + `"`
+		if r.Header.Get("If-None-Match") == etag {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("ETag", etag)
+		if r.URL.Query().Get("lastEventId") == "" {
+			fmt.Fprintln(w, `[{"id":"1"}]`)
+			return
+		}
+		fmt.Fprintln(w, `[]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{ConditionalRequests: true})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Expect the ETag to be sent only when a poll is repeated
+	events, err := client.fetchEvents(ts.URL, "", ctx)
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+
+	events, err = client.fetchEvents(ts.URL, "1", ctx)
+	assert.NoError(t, err)
+	assert.Len(t, events, 0)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&notModified))
+
+	// 3. Expect a 304 response to be treated as no new events
+	events, err = client.fetchEvents(ts.URL, "1", ctx)
+	assert.NoError(t, err)
+	assert.Len(t, events, 0)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&notModified))
+}
+
+func TestClient_fetchEvents_IfModifiedSince(t *testing.T) {
+	var notModified int32
+	lastModified := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC).Format(http.TimeFormat)
+
+	// 1. Set up a test server supporting Last-Modified, but not ETags
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "", r.Header.Get("If-None-Match"))
+		if r.Header.Get("If-Modified-Since") == lastModified {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("Last-Modified", lastModified)
+		w.Header().Set("ETag", `"1"`)
+		fmt.Fprintln(w, `[]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{IfModifiedSince: true})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Expect a repeated poll to send the date and a 304 response to be treated as no new events
+	events, err := client.fetchEvents(ts.URL, "1", ctx)
+	assert.NoError(t, err)
+	assert.Len(t, events, 0)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&notModified))
+
+	events, err = client.fetchEvents(ts.URL, "1", ctx)
+	assert.NoError(t, err)
+	assert.Len(t, events, 0)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&notModified))
+}
+
+func TestClient_Subscribe_MutualTLS(t *testing.T) {
+	// 1. Create a client certificate
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "client"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	clientCert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+
+	// 2. Set up a long-polling test server requiring the client certificate
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "1000", r.URL.Query().Get("timeout"))
+		assert.Equal(t, "client", r.TLS.PeerCertificates[0].Subject.CommonName)
+		fmt.Fprintln(w, `[{"id":"1"}]`)
+	}))
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert)
+	ts.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	ts.StartTLS()
+	defer ts.Close()
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(ts.Certificate())
+
+	// 3. Expect requests without the client certificate to fail
+	_, err = NewClient(ClientOptions{
+		TLSConfig: &tls.Config{RootCAs: rootCAs},
+	}).fetchEvents(ts.URL, "", context.Background())
+	assert.Error(t, err)
+
+	// 4. Expect the events to be received with the client certificate
+	client := NewClient(ClientOptions{
+		Timeout: 1 * time.Second,
+		TLSConfig: &tls.Config{
+			RootCAs:      rootCAs,
+			Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	events := make(chan Event)
+	go func() {
+		_ = client.Subscribe(ts.URL, "", events, ctx)
+	}()
+
+	assert.Equal(t, "1", (<-events).ID)
+}
+
+func TestClient_Subscribe_OnCaughtUp(t *testing.T) {
+	// 1. Set up a test server returning five events in batches of two
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("lastEventId") {
+		case "":
+			fmt.Fprintln(w, `[{"id":"1"},{"id":"2"}]`)
+		case "2":
+			fmt.Fprintln(w, `[{"id":"3"},{"id":"4"}]`)
+		case "4":
+			fmt.Fprintln(w, `[{"id":"5"}]`)
+		default:
+			fmt.Fprintln(w, `[]`)
+		}
+	}))
+	defer ts.Close()
+
+	caughtUp := make(chan string, 2)
+	client := NewClient(ClientOptions{
+		PollDelay: 10 * time.Millisecond,
+		BatchSize: 2,
+		OnCaughtUp: func(endpoint string) {
+			caughtUp <- endpoint
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	events := make(chan Event)
+	go func() {
+		_ = client.Subscribe(ts.URL, "", events, ctx)
+	}()
+
+	// 2. Expect no signal while full batches are returned
+	for i := 1; i <= 4; i++ {
+		<-events
+	}
+	assert.Len(t, caughtUp, 0)
+
+	// 3. Expect the signal once after the last batch
+	assert.Equal(t, "5", (<-events).ID)
+	assert.Equal(t, ts.URL, <-caughtUp)
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Len(t, caughtUp, 0)
+}
+
+func TestClient_SubscribeAck(t *testing.T) {
+	// 1. Set up a test server returning two events
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("lastEventId") {
+		case "":
+			fmt.Fprintln(w, `[{"id":"1"},{"id":"2"}]`)
+		case "1":
+			fmt.Fprintln(w, `[{"id":"2"}]`)
+		default:
+			fmt.Fprintln(w, `[]`)
+		}
+	}))
+	defer ts.Close()
+
+	cursor := NewFileCursor(filepath.Join(t.TempDir(), "cursor"))
+	errs := make(chan error, 10)
+	client := NewClient(ClientOptions{
+		PollDelay: 10 * time.Millisecond,
+		Cursor:    cursor,
+		ErrorHandler: func(err error) {
+			errs <- err
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Fail the second event once, then stop without acknowledging it
+	var ids []string
+	err := client.SubscribeAck(ts.URL, "", func(e Event) error {
+		ids = append(ids, e.ID)
+		switch len(ids) {
+		case 2:
+			return errors.New("could not persist event")
+		case 3:
+			return ErrStopIteration
+		}
+		return nil
+	}, ctx)
+	assert.NoError(t, err)
+
+	// 3. Expect the failed event to be delivered again and the cursor not to advance past it
+	assert.Equal(t, []string{"1", "2", "2"}, ids)
+	assert.ErrorContains(t, <-errs, "could not persist event")
+
+	stored, err := cursor.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, "1", stored)
+}
+
+func TestClient_SubscribeBatches(t *testing.T) {
+	// 1. Set up a test server returning two batches
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("lastEventId") {
+		case "":
+			fmt.Fprintln(w, `[{"id":"1"},{"id":"2"},{"id":"3"}]`)
+		case "3":
+			fmt.Fprintln(w, `[{"id":"4"}]`)
+		default:
+			fmt.Fprintln(w, `[]`)
+		}
+	}))
+	defer ts.Close()
+
+	cursor := NewFileCursor(filepath.Join(t.TempDir(), "cursor"))
+	client := NewClient(ClientOptions{
+		PollDelay: 10 * time.Millisecond,
+		Cursor:    cursor,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	batches := make(chan []Event)
+	go func() {
+		_ = client.SubscribeBatches(ts.URL, "", batches, ctx)
+	}()
+
+	// 2. Expect the cursor not to advance before the batch is received
+	time.Sleep(50 * time.Millisecond)
+	stored, err := cursor.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, "", stored)
+
+	// 3. Expect the events of each poll in one batch
+	batch := <-batches
+	assert.Len(t, batch, 3)
+	assert.Equal(t, "1", batch[0].ID)
+	assert.Equal(t, ts.URL, batch[0].Endpoint)
+
+	batch = <-batches
+	assert.Len(t, batch, 1)
+	assert.Equal(t, "4", batch[0].ID)
+
+	assert.Eventually(t, func() bool {
+		stored, _ := cursor.Load()
+		return stored == "4"
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestClient_SubscribeBatches_RollbackOnFailure(t *testing.T) {
+	var polls int32
+	lastEventIds := make(chan string, 100)
+
+	// 1. Set up a test server which breaks off the first response after an event of another type
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		lastEventIds <- r.URL.Query().Get("lastEventId")
+		if atomic.AddInt32(&polls, 1) == 1 {
+			fmt.Fprint(w, `[{"id":"1","type":"a"},{"id":"2","type":"b"},{"id":`)
+			return
+		}
+
+		switch r.URL.Query().Get("lastEventId") {
+		case "":
+			fmt.Fprintln(w, `[{"id":"1","type":"a"},{"id":"2","type":"b"}]`)
+		default:
+			fmt.Fprintln(w, `[]`)
+		}
+	}))
+	defer ts.Close()
+
+	cursor := NewFileCursor(filepath.Join(t.TempDir(), "cursor"))
+	client := NewClient(ClientOptions{
+		PollDelay: 10 * time.Millisecond,
+		Types:     []string{"a"},
+		Cursor:    cursor,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	batches := make(chan []Event)
+	go func() {
+		_ = client.SubscribeBatches(ts.URL, "", batches, ctx)
+	}()
+
+	// 2. Expect the undelivered event to be fetched again instead of skipping past it
+	batch := <-batches
+	assert.Len(t, batch, 1)
+	assert.Equal(t, "1", batch[0].ID)
+
+	// 3. Expect the skipped event to be committed once the batch before it has been delivered
+	assert.Equal(t, "", <-lastEventIds)
+	assert.Equal(t, "", <-lastEventIds)
+	assert.Equal(t, "2", <-lastEventIds)
+
+	stored, err := cursor.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, "1", stored)
+}
+
+func TestClient_Subscribe_StartupJitter(t *testing.T) {
+	var requests int32
+
+	// 1. Set up a test server
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		fmt.Fprintln(w, `[]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{
+		PollDelay:     time.Hour,
+		StartupJitter: 100 * time.Millisecond,
+	})
+
+	// 2. Expect the first poll within the jitter
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	go func() {
+		_ = client.Subscribe(ts.URL, "", make(chan Event), ctx)
+	}()
+
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&requests) == 1
+	}, 200*time.Millisecond, 5*time.Millisecond)
+
+	// 3. Expect the jitter to be aborted by the context
+	client = NewClient(ClientOptions{StartupJitter: time.Hour})
+
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := client.Subscribe(ts.URL, "", make(chan Event), ctx)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
+
+func TestClient_Subscribe_RepollFullBatches(t *testing.T) {
+	// 1. Set up a test server returning five events in batches of two
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("lastEventId") {
+		case "":
+			fmt.Fprintln(w, `[{"id":"1"},{"id":"2"}]`)
+		case "2":
+			fmt.Fprintln(w, `[{"id":"3"},{"id":"4"}]`)
+		case "4":
+			fmt.Fprintln(w, `[{"id":"5"}]`)
+		default:
+			t.Error("expected no poll after the last batch")
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{
+		PollDelay: time.Hour,
+		BatchSize: 2,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	events := make(chan Event)
+	go func() {
+		_ = client.Subscribe(ts.URL, "", events, ctx)
+	}()
+
+	// 2. Expect all events without waiting for the poll delay
+	for i := 1; i <= 5; i++ {
+		assert.Equal(t, strconv.Itoa(i), (<-events).ID)
+	}
+	time.Sleep(50 * time.Millisecond)
+}
+
+func TestClient_concurrentSubscriptions(t *testing.T) {
+	store := NewInMemoryStore(InMemoryStoreOptions{})
+	store.Append(Event{}, Event{}, Event{})
+
+	// 1. Set up a feed server with a few events
+	ts := httptest.NewServer(NewFeedHandler(store, FeedHandlerOptions{BatchSize: 2}))
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{
+		PollDelay:           10 * time.Millisecond,
+		ConditionalRequests: true,
+		DeduplicationWindow: 10,
+		Redirects:           RedirectOptions{Pin: true},
+		CircuitBreaker:      CircuitBreakerOptions{FailureThreshold: 3},
+		MaxEvents:           3,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	// 2. Run several subscriptions on the same client while reading its stats, to be run with -race
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(endpoint string) {
+			defer wg.Done()
+
+			var ids []string
+			err := client.SubscribeFunc(endpoint, "", func(e Event) error {
+				ids = append(ids, e.ID)
+				_ = client.Stats()
+				return nil
+			}, ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"1", "2", "3"}, ids)
+		}(fmt.Sprintf("%s/feed/%d", ts.URL, i%2))
+	}
+	wg.Wait()
+
+	// 3. Expect the stats of all subscriptions
+	stats := client.Stats()
+	assert.Equal(t, int64(24), stats.EventsDelivered)
+	assert.Equal(t, int64(12), stats.Feeds[ts.URL+"/feed/0"].EventsDelivered)
+}
+
+func TestClient_Subscribe_MaxEvents(t *testing.T) {
+	// 1. Set up a test server returning five events
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `[{"id":"1"},{"id":"2"},{"id":"3"},{"id":"4"},{"id":"5"}]`)
+	}))
+	defer ts.Close()
+
+	cursor := NewFileCursor(filepath.Join(t.TempDir(), "cursor"))
+	client := NewClient(ClientOptions{
+		PollDelay: 10 * time.Millisecond,
+		Cursor:    cursor,
+		MaxEvents: 3,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Expect the subscription to end after three events
+	var ids []string
+	err := client.SubscribeFunc(ts.URL, "", func(e Event) error {
+		ids = append(ids, e.ID)
+		return nil
+	}, ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "2", "3"}, ids)
+
+	stored, err := cursor.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, "3", stored)
+
+	// 3. Expect a batch to be cut at the limit
+	client = NewClient(ClientOptions{MaxEvents: 2})
+	batches := make(chan []Event, 1)
+	err = client.SubscribeBatches(ts.URL, "", batches, ctx)
+	assert.NoError(t, err)
+	assert.Len(t, <-batches, 2)
+}
+
+func TestClient_Subscribe_MaxDuration(t *testing.T) {
+	// 1. Set up a long-polling test server which holds the requests after the first event
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("lastEventId") == "" {
+			fmt.Fprintln(w, `[{"id":"1"}]`)
+			return
+		}
+
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+		fmt.Fprintln(w, `[]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{
+		Timeout:     time.Second,
+		MaxDuration: 100 * time.Millisecond,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	// 2. Expect the subscription to end with ErrMaxDurationElapsed, cancelling the request in flight
+	var ids []string
+	start := time.Now()
+	err := client.SubscribeFunc(ts.URL, "", func(e Event) error {
+		ids = append(ids, e.ID)
+		return nil
+	}, ctx)
+	assert.ErrorIs(t, err, ErrMaxDurationElapsed)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.Equal(t, []string{"1"}, ids)
+	assert.NoError(t, ctx.Err())
+}
+
+func TestClient_Subscribe_StopOnError(t *testing.T) {
+	// 1. Set up a test server failing every request
+	var requests atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{
+		PollDelay:   10 * time.Millisecond,
+		StopOnError: true,
+		ErrorHandler: func(err error) {
+			t.Errorf("unexpected call of the ErrorHandler: %v", err)
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Expect the first error to end the subscription without retrying
+	err := client.Subscribe(ts.URL, "", make(chan Event), ctx)
+	var httpErr *FeedHTTPError
+	assert.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusServiceUnavailable, httpErr.StatusCode)
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestClient_Subscribe_OnHeartbeat(t *testing.T) {
+	// 1. Set up a long-polling test server without new events after the first one
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("lastEventId") == "" {
+			fmt.Fprintln(w, `[{"id":"1"}]`)
+			return
+		}
+
+		time.Sleep(20 * time.Millisecond)
+		fmt.Fprintln(w, `[]`)
+	}))
+	defer ts.Close()
+
+	heartbeats := make(chan string, 10)
+	client := NewClient(ClientOptions{
+		PollDelay: 10 * time.Millisecond,
+		Timeout:   20 * time.Millisecond,
+		OnHeartbeat: func(endpoint string) {
+			heartbeats <- endpoint
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	events := make(chan Event)
+	go func() {
+		_ = client.Subscribe(ts.URL, "", events, ctx)
+	}()
+
+	// 2. Expect no heartbeat for the poll with an event, but for the empty polls after it
+	assert.Equal(t, "1", (<-events).ID)
+	assert.Equal(t, ts.URL, <-heartbeats)
+	assert.Equal(t, ts.URL, <-heartbeats)
+}
+
+func TestClient_Subscribe_OnPoll(t *testing.T) {
+	var requests atomic.Int32
+
+	// 1. Set up a long-polling test server with an event, a failing poll and then no new events
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch requests.Add(1) {
+		case 1:
+			fmt.Fprintln(w, `[{"id":"1"},{"id":"2"}]`)
+		case 2:
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			fmt.Fprintln(w, `[]`)
+		}
+	}))
+	defer ts.Close()
+
+	results := make(chan PollResult, 10)
+	client := NewClient(ClientOptions{
+		PollDelay: 10 * time.Millisecond,
+		Timeout:   20 * time.Millisecond,
+		OnPoll: func(result PollResult) {
+			results <- result
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	go func() {
+		_ = client.SubscribeFunc(ts.URL, "", func(Event) error { return nil }, ctx)
+	}()
+
+	// 2. Expect a result for every poll
+	result := <-results
+	assert.Equal(t, ts.URL, result.Endpoint)
+	assert.Equal(t, 2, result.Events)
+	assert.Greater(t, result.Duration, time.Duration(0))
+	assert.False(t, result.LongPollTimeout)
+	assert.NoError(t, result.Err)
+
+	result = <-results
+	assert.Error(t, result.Err)
+	assert.False(t, result.LongPollTimeout)
+
+	result = <-results
+	assert.Equal(t, 0, result.Events)
+	assert.True(t, result.LongPollTimeout)
+	assert.NoError(t, result.Err)
+}
+
+func TestClient_fetchEvents_OnResponse(t *testing.T) {
+	// 1. Set up a test server sending rate limit headers
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-RateLimit-Remaining", "41")
+		fmt.Fprintln(w, `[{"id":"1"}]`)
+	}))
+	defer ts.Close()
+
+	var remaining string
+	client := NewClient(ClientOptions{
+		AcceptMediaTypes: FeedMediaTypes,
+		OnResponse: func(resp *http.Response) {
+			remaining = resp.Header.Get("X-RateLimit-Remaining")
+
+			// Expect the hook not to be able to interfere with the processing of the response
+			resp.Header.Del("Content-Type")
+			b, err := io.ReadAll(resp.Body)
+			assert.NoError(t, err)
+			assert.Empty(t, b)
+			assert.NoError(t, resp.Body.Close())
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Expect the headers to be passed to the hook and the events to be decoded as usual
+	events, err := client.fetchEvents(ts.URL, "", ctx)
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, "41", remaining)
+}
+
+func TestClient_Subscribe_RequestID(t *testing.T) {
+	// 1. Set up a test server recording the request IDs
+	requestIDs := make(chan string, 10)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestIDs <- r.Header.Get(RequestIDHeader)
+		fmt.Fprintln(w, `[]`)
+	}))
+	defer ts.Close()
+
+	var counter atomic.Int32
+	results := make(chan PollResult, 10)
+	client := NewClient(ClientOptions{
+		PollDelay: 10 * time.Millisecond,
+		RequestIDGenerator: func() string {
+			return fmt.Sprintf("poll-%d", counter.Add(1))
+		},
+		OnPoll: func(result PollResult) {
+			results <- result
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	go func() {
+		_ = client.Subscribe(ts.URL, "", make(chan Event), ctx)
+	}()
+
+	// 2. Expect every poll to send its own ID and report it in the result
+	assert.Equal(t, "poll-1", <-requestIDs)
+	assert.Equal(t, "poll-1", (<-results).RequestID)
+	assert.Equal(t, "poll-2", <-requestIDs)
+	assert.Equal(t, "poll-2", (<-results).RequestID)
+}
+
+func TestClient_fetchEvents_KeepRawEvents(t *testing.T) {
+	raw := `{"type":"t",  "id":"1", "data":{"b":2,"a":1}}`
+
+	// 1. Set up a test server
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "[%s]", raw)
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Expect the original bytes only if enabled
+	events, err := NewClient(ClientOptions{}).fetchEvents(ts.URL, "", ctx)
+	assert.NoError(t, err)
+	assert.Nil(t, events[0].Raw)
+
+	events, err = NewClient(ClientOptions{KeepRawEvents: true}).fetchEvents(ts.URL, "", ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", events[0].ID)
+	assert.Equal(t, raw, string(events[0].Raw))
+}
+
+func TestClient_fetchEvents_AcceptMediaTypes(t *testing.T) {
+	// 1. Set up a test server
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("lastEventId") == "html" {
+			w.Header().Set("Content-Type", "text/html")
+			fmt.Fprintln(w, `<html></html>`)
+			return
+		}
+
+		w.Header().Set("Content-Type", MediaTypeCloudEventsBatch+"; charset=utf-8")
+		fmt.Fprintln(w, `[{"id":"1"}]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{AcceptMediaTypes: FeedMediaTypes})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Test for successful response
+	events, err := client.fetchEvents(ts.URL, "", ctx)
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+
+	// 3. Test that other content types are rejected
+	_, err = client.fetchEvents(ts.URL, "html", ctx)
+	assert.True(t, errors.Is(err, ErrUnexpectedContentType))
+	assert.ErrorContains(t, err, "text/html")
+}
+
+func TestClient_fetchEvents_setAcceptHeader(t *testing.T) {
+	var accept string
+
+	// 1. Set up a test server
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accept = r.Header.Get("Accept")
+		fmt.Fprintln(w, `[]`)
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Test the default Accept header for simple and long polling
+	client := NewClient(ClientOptions{})
+	_, err := client.fetchEvents(ts.URL, "", ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "application/cloudevents-batch+json, application/json", accept)
+
+	client = NewClient(ClientOptions{Timeout: 10 * time.Millisecond})
+	_, err = client.fetchEvents(ts.URL, "", ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "application/cloudevents-batch+json, application/json", accept)
+
+	// 3. Test overriding the Accept header
+	client = NewClient(ClientOptions{Accept: "application/json"})
+	_, err = client.fetchEvents(ts.URL, "", ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "application/json", accept)
+}
+
+func TestClient_fetchEvents_EnableCompression(t *testing.T) {
+	var acceptEncoding string
+
+	// 1. Set up a test server that compresses its response
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		acceptEncoding = r.Header.Get("Accept-Encoding")
+
+		w.Header().Set("Content-Type", MediaTypeCloudEventsBatch)
+		w.Header().Set("Content-Encoding", "gzip")
+
+		gz := gzip.NewWriter(w)
+		defer gz.Close()
+		fmt.Fprintln(gz, `[{"id":"1"},{"id":"2"}]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{
+		EnableCompression: true,
+		AcceptMediaTypes:  FeedMediaTypes,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Test that the response is decompressed
+	events, err := client.fetchEvents(ts.URL, "", ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "gzip", acceptEncoding)
+	assert.Len(t, events, 2)
+	assert.Equal(t, "2", events[1].ID)
+}
+
+func TestClient_streamEvents(t *testing.T) {
+	next := make(chan struct{})
+
+	// 1. Set up a test server that sends the second event only after the first one has been handled
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id":"1"},`)
+		w.(http.Flusher).Flush()
+
+		<-next
+		fmt.Fprint(w, `{"id":"2"}]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Test that the events are handled while the response is decoded
+	var ids []string
+	n, err := client.streamEvents(ts.URL, "", func(e Event) error {
+		ids = append(ids, e.ID)
+		if e.ID == "1" {
+			close(next)
+		}
+		return nil
+	}, ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []string{"1", "2"}, ids)
+}
+
+func TestClient_fetchEvents_invalidBody(t *testing.T) {
+	// 1. Set up a test server
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"id":"1"}`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Test that anything but an array is rejected
+	_, err := client.fetchEvents(ts.URL, "", ctx)
+	assert.ErrorContains(t, err, "expected a JSON array")
+}
+
+func TestClient_SubscribeFunc(t *testing.T) {
+	// 1. Setup a test server
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("lastEventId") == "2" {
+			fmt.Fprintln(w, `[{"id":"3"}]`)
+			return
+		}
+
+		fmt.Fprintln(w, `[{"id":"1"},{"id":"2"}]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{PollDelay: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Expect the subscription to end without an error on ErrStopIteration
+	var ids []string
+	err := client.SubscribeFunc(ts.URL, "", func(e Event) error {
+		ids = append(ids, e.ID)
+		if e.ID == "3" {
+			return ErrStopIteration
+		}
+		return nil
+	}, ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "2", "3"}, ids)
+}
+
+func TestClient_SubscribeFunc_handlerError(t *testing.T) {
+	// 1. Setup a test server
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `[{"id":"1"},{"id":"2"}]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{PollDelay: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Expect the handler error to end the subscription
+	handlerErr := errors.New("handler failed")
+	err := client.SubscribeFunc(ts.URL, "", func(e Event) error {
+		return handlerErr
+	}, ctx)
+	assert.True(t, errors.Is(err, handlerErr))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	delay, ok := parseRetryAfter("120", now)
+	assert.True(t, ok)
+	assert.Equal(t, 120*time.Second, delay)
+
+	delay, ok = parseRetryAfter("Mon, 01 Jan 2024 12:00:30 GMT", now)
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Second, delay)
+
+	_, ok = parseRetryAfter("", now)
+	assert.False(t, ok)
+
+	_, ok = parseRetryAfter("soon", now)
+	assert.False(t, ok)
+}
+
+func TestClient_Subscribe_RetryAfter(t *testing.T) {
+	var mu sync.Mutex
+	var requests []time.Time
+
+	// 1. Setup a test server that rate limits the first request
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, time.Now())
+		n := len(requests)
+		mu.Unlock()
+
+		if n == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		fmt.Fprintln(w, `[{"id":"1"}]`)
+	}))
+	defer ts.Close()
+
+	events := make(chan Event)
+	client := NewClient(ClientOptions{PollDelay: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	go func() {
+		_ = client.Subscribe(ts.URL, "", events, ctx)
+	}()
+
+	ev := <-events
+	assert.Equal(t, "1", ev.ID)
+
+	// 2. Expect the client to wait as long as requested by the server
+	mu.Lock()
+	defer mu.Unlock()
+	assert.GreaterOrEqual(t, requests[1].Sub(requests[0]), 1*time.Second)
+}
+
+func TestClient_Subscribe_RetryAfterRecovery(t *testing.T) {
+	var mu sync.Mutex
+	var requests []time.Time
+
+	// 1. Setup a long-polling test server that rate limits the first request
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, time.Now())
+		n := len(requests)
+		mu.Unlock()
+
+		if n == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		fmt.Fprintln(w, `[]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{
+		PollDelay: 50 * time.Millisecond,
+		Timeout:   time.Second,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	go func() {
+		_ = client.Subscribe(ts.URL, "", make(chan Event), ctx)
+	}()
+
+	// 2. Expect the regular poll delay again after the first successful poll
+	recovered := assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(requests) >= 6
+	}, 2*time.Second, 10*time.Millisecond)
+	if !recovered {
+		return
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.GreaterOrEqual(t, requests[1].Sub(requests[0]), 1*time.Second)
+	assert.Less(t, requests[5].Sub(requests[1]), 1*time.Second)
+}
+
+func TestClient_Subscribe_Logger(t *testing.T) {
+	// 1. Setup a test server
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `[{"id":"1"}]`)
+	}))
+	defer ts.Close()
+
+	var mu sync.Mutex
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&lockedWriter{mu: &mu, w: &buf}, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	events := make(chan Event)
+	client := NewClient(ClientOptions{
+		PollDelay: 10 * time.Millisecond,
+		Logger:    logger,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	go func() {
+		_ = client.Subscribe(ts.URL, "", events, ctx)
+	}()
+
+	<-events
+
+	// 2. Expect the poll to be logged
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Contains(c, buf.String(), `msg="polling feed"`)
+		assert.Contains(c, buf.String(), `msg="received events"`)
+	}, 1*time.Second, 10*time.Millisecond)
+}
+
+type lockedWriter struct {
+	mu *sync.Mutex
+	w  io.Writer
+}
+
+func (w *lockedWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.w.Write(p)
+}
+
+type testMetrics struct {
+	polls          int32
+	eventsReceived int32
+	fetchErrors    int32
+}
+
+func (m *testMetrics) ObservePollDuration(time.Duration) { atomic.AddInt32(&m.polls, 1) }
+func (m *testMetrics) IncEventsReceived(n int)           { atomic.AddInt32(&m.eventsReceived, int32(n)) }
+func (m *testMetrics) IncFetchError()                    { atomic.AddInt32(&m.fetchErrors, 1) }
+
+func TestClient_Subscribe_Metrics(t *testing.T) {
+	var requests int32
+
+	// 1. Setup a test server that fails the first request
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		fmt.Fprintln(w, `[{"id":"1"},{"id":"2"}]`)
+	}))
+	defer ts.Close()
+
+	metrics := &testMetrics{}
+	events := make(chan Event)
+	client := NewClient(ClientOptions{
+		PollDelay: 10 * time.Millisecond,
+		Metrics:   metrics,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	go func() {
+		_ = client.Subscribe(ts.URL, "", events, ctx)
+	}()
+
+	<-events
+	<-events
+
+	// 2. Expect the failed and the successful poll to be measured
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		assert.GreaterOrEqual(c, atomic.LoadInt32(&metrics.polls), int32(2))
+		assert.GreaterOrEqual(c, atomic.LoadInt32(&metrics.eventsReceived), int32(2))
+		assert.Equal(c, int32(1), atomic.LoadInt32(&metrics.fetchErrors))
+	}, 1*time.Second, 10*time.Millisecond)
+}
+
+func TestNewClient_requestTimeout(t *testing.T) {
+	client := NewClient(ClientOptions{})
+	assert.Equal(t, DefaultRequestTimeout, client.requestTimeout)
+
+	// Long-polling derives the request timeout from the timeout
+	client = NewClient(ClientOptions{Timeout: 60 * time.Second})
+	assert.Equal(t, 65*time.Second, client.requestTimeout)
+
+	client = NewClient(ClientOptions{Timeout: 60 * time.Second, RequestTimeout: 90 * time.Second})
+	assert.Equal(t, 90*time.Second, client.requestTimeout)
+
+	client = NewClient(ClientOptions{Timeout: 60 * time.Second, LongPollMargin: time.Second})
+	assert.Equal(t, 61*time.Second, client.requestTimeout)
+}
+
+// closingCursor is a FileCursor that records whether it has been closed.
+type closingCursor struct {
+	*FileCursor
+	closed bool
+}
+
+func (c *closingCursor) Close() error {
+	c.closed = true
+	return errors.New("flush failed")
+}
+
+func TestClient_Close(t *testing.T) {
+	var closedConns atomic.Int32
+
+	// 1. Set up a test server counting the closed connections
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `[]`)
+	}))
+	ts.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateClosed {
+			closedConns.Add(1)
+		}
+	}
+	ts.Start()
+	defer ts.Close()
+
+	cursor := &closingCursor{FileCursor: NewFileCursor(filepath.Join(t.TempDir(), "cursor"))}
+	client := NewClient(ClientOptions{
+		Connection: ConnectionOptions{MaxIdleConnsPerHost: 4},
+		Cursor:     cursor,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	_, _, err := client.Fetch(ts.URL, "", ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), closedConns.Load())
+
+	// 2. Expect the idle connection and the cursor to be closed
+	err = client.Close()
+	assert.EqualError(t, err, "flush failed")
+	assert.True(t, cursor.closed)
+	assert.Eventually(t, func() bool {
+		return closedConns.Load() == 1
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestClient_Subscribe_cancelMidDelivery(t *testing.T) {
+	// 1. Setup a test server
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `[{"id":"1"},{"id":"2"}]`)
+	}))
+	defer ts.Close()
+
+	events := make(chan Event)
+	client := NewClient(ClientOptions{PollDelay: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	result := make(chan error)
+	go func() {
+		result <- client.Subscribe(ts.URL, "", events, ctx)
+	}()
+
+	// 2. Stop reading after the first event and cancel the subscription
+	ev := <-events
+	assert.Equal(t, "1", ev.ID)
+	cancel()
+
+	select {
+	case err := <-result:
+		assert.True(t, errors.Is(err, context.Canceled))
+	case <-time.After(1 * time.Second):
+		t.Fatal("subscription is blocked on sending the second event")
+	}
+}
+
+func TestClient_fetchEvents_ParamNames(t *testing.T) {
+	// 1. Set up a test server expecting custom query parameters
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "5", r.URL.Query().Get("cursor"))
+		assert.Equal(t, "100", r.URL.Query().Get("wait"))
+		assert.False(t, r.URL.Query().Has("lastEventId"))
+		assert.False(t, r.URL.Query().Has("timeout"))
+		fmt.Fprintln(w, `[{"id":"6"}]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{
+		Timeout:          100 * time.Millisecond,
+		CursorParamName:  "cursor",
+		TimeoutParamName: "wait",
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Expect the request to use the custom parameter names
+	events, err := client.fetchEvents(ts.URL, "5", ctx)
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+}
+
+func TestClient_Subscribe_Types(t *testing.T) {
+	// 1. Set up a test server which ignores the type parameter
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, []string{"order.created", "order.paid"}, r.URL.Query()["kind"])
+
+		switch r.URL.Query().Get("lastEventId") {
+		case "":
+			fmt.Fprintln(w, `[{"id":"1","type":"order.created"},{"id":"2","type":"order.shipped"}]`)
+		case "2":
+			fmt.Fprintln(w, `[{"id":"3","type":"order.shipped"},{"id":"4","type":"order.paid"}]`)
+		default:
+			fmt.Fprintln(w, `[]`)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{
+		PollDelay:      10 * time.Millisecond,
+		Types:          []string{"order.created", "order.paid"},
+		TypesParamName: "kind",
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	events := make(chan Event)
+	go func() {
+		_ = client.Subscribe(ts.URL, "", events, ctx)
+	}()
+
+	// 2. Expect the events of other types to be skipped by the client
+	assert.Equal(t, "1", (<-events).ID)
+	assert.Equal(t, "4", (<-events).ID)
+}
+
+func TestClient_Subscribe_CursorInclusive(t *testing.T) {
+	// 1. Set up a test server which returns the event at the cursor again
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("lastEventId") {
+		case "":
+			fmt.Fprintln(w, `[{"id":"1"},{"id":"2"}]`)
+		case "2":
+			fmt.Fprintln(w, `[{"id":"2"},{"id":"3"}]`)
+		default:
+			fmt.Fprintln(w, `[{"id":"3"}]`)
+		}
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Expect the boundary event to be delivered again with an exclusive cursor
+	events, _, err := NewClient(ClientOptions{}).Fetch(ts.URL, "2", ctx)
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+
+	// 3. Expect the boundary event to be dropped with an inclusive cursor
+	client := NewClient(ClientOptions{
+		PollDelay:       10 * time.Millisecond,
+		CursorInclusive: true,
+	})
+
+	events, lastEventId, err := client.Fetch(ts.URL, "2", ctx)
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, "3", lastEventId)
+
+	// 4. Expect every event to be delivered once by a subscription
+	received := make(chan Event)
+	go func() {
+		_ = client.Subscribe(ts.URL, "", received, ctx)
+	}()
+
+	assert.Equal(t, "1", (<-received).ID)
+	assert.Equal(t, "2", (<-received).ID)
+	assert.Equal(t, "3", (<-received).ID)
+
+	select {
+	case event := <-received:
+		assert.Fail(t, "unexpected event", event.ID)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestClient_Subscribe_SubscriberID(t *testing.T) {
+	// 1. Set up a test server which keeps the cursor of each subscriber
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "billing", r.URL.Query().Get("consumer"))
+
+		query := r.URL.Query()
+		switch {
+		case !query.Has("lastEventId"):
+			fmt.Fprintln(w, `[{"id":"5"}]`)
+		case query.Get("lastEventId") == "5":
+			fmt.Fprintln(w, `[{"id":"6"}]`)
+		default:
+			fmt.Fprintln(w, `[]`)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{
+		PollDelay:             10 * time.Millisecond,
+		SubscriberID:          "billing",
+		SubscriberIDParamName: "consumer",
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	events := make(chan Event)
+	go func() {
+		_ = client.Subscribe(ts.URL, "", events, ctx)
+	}()
+
+	// 2. Expect the server's cursor to be used first and the client's cursor afterwards
+	assert.Equal(t, "5", (<-events).ID)
+	assert.Equal(t, "6", (<-events).ID)
+}
+
+func TestClient_fetchEvents_Method(t *testing.T) {
+	// 1. Set up a test server answering HEAD requests like GET requests without the body
+	methods := make(chan string, 10)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods <- r.Method
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, `[{"id":"1"}]`)
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Expect a successful HEAD request to be treated as a poll without events
+	client := NewClient(ClientOptions{Method: http.MethodHead})
+	events, err := client.fetchEvents(ts.URL, "", ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+	assert.Equal(t, http.MethodHead, <-methods)
+
+	// 3. Expect GET by default
+	client = NewClient(ClientOptions{})
+	events, err = client.fetchEvents(ts.URL, "", ctx)
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, http.MethodGet, <-methods)
+
+	// 4. Expect the method to be case-insensitive, and unsafe methods to be replaced by GET
+	client = NewClient(ClientOptions{Method: "head"})
+	_, err = client.fetchEvents(ts.URL, "", ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodHead, <-methods)
+
+	client = NewClient(ClientOptions{Method: http.MethodDelete})
+	_, err = client.fetchEvents(ts.URL, "", ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodGet, <-methods)
+}
+
+func TestClient_fetchEvents_RequestBody(t *testing.T) {
+	// 1. Set up a test server filtering by the request body
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "1", r.URL.Query().Get("lastEventId"))
+
+		var filter struct {
+			Types []string `json:"types"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&filter))
+		assert.Equal(t, []string{"order.paid"}, filter.Types)
+
+		fmt.Fprintln(w, `[{"id":"2","type":"order.paid"}]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{
+		RequestBody: map[string]interface{}{"types": []string{"order.paid"}},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Expect the body to be sent with a POST request
+	events, err := client.fetchEvents(ts.URL, "1", ctx)
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+}
+
+func TestClient_fetchEvents_Headers(t *testing.T) {
+	var header http.Header
+
+	// 1. Set up a test server
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		fmt.Fprintln(w, `[]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{
+		AuthToken: "secret",
+		Headers: http.Header{
+			"X-Tenant-Id":   []string{"tenant"},
+			"Accept":        []string{"text/html"},
+			"Authorization": []string{"Basic xyz"},
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Test that the custom headers are sent without replacing the client's headers
+	_, err := client.fetchEvents(ts.URL, "", ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "tenant", header.Get("X-Tenant-Id"))
+	assert.Equal(t, DefaultAccept, header.Get("Accept"))
+	assert.Equal(t, "Bearer secret", header.Get("Authorization"))
+}
+
+func TestClient_fetchEvents_UserAgent(t *testing.T) {
+	userAgents := make(chan string, 2)
+
+	// 1. Set up a test server
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgents <- r.Header.Get("User-Agent")
+		fmt.Fprintln(w, `[]`)
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Expect the default and the configured User-Agent
+	_, err := NewClient(ClientOptions{}).fetchEvents(ts.URL, "", ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultUserAgent, <-userAgents)
+	assert.True(t, strings.HasPrefix(DefaultUserAgent, "go-http-feeds/"))
+
+	_, err = NewClient(ClientOptions{UserAgent: "orders-consumer/1.0"}).fetchEvents(ts.URL, "", ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "orders-consumer/1.0", <-userAgents)
+}
+
+func TestClient_fetchEvents_FeedHTTPError(t *testing.T) {
+	// 1. Set up a test server
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "5")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(w, "maintenance")
+	}))
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Test that the error response can be inspected
+	_, err := client.fetchEvents(ts.URL, "", ctx)
+
+	var httpErr *FeedHTTPError
+	assert.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, http.StatusServiceUnavailable, httpErr.StatusCode)
+	assert.Equal(t, "503 Service Unavailable", httpErr.Status)
+	assert.Equal(t, "maintenance", string(httpErr.Body))
+	assert.Equal(t, 5*time.Second, httpErr.RetryAfter)
+}
+
+func TestClient_fetchEvents_ChunkedErrorBody(t *testing.T) {
+	// 1. Set up a test server sending a chunked error body without a content length
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		fmt.Fprint(w, "upstream ")
+		w.(http.Flusher).Flush()
+		fmt.Fprint(w, "unavailable")
+		fmt.Fprint(w, strings.Repeat("x", MaxErrorBodyBytes))
+	}))
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Expect the body to be read up to the limit
+	_, err := client.fetchEvents(ts.URL, "", ctx)
+
+	var httpErr *FeedHTTPError
+	assert.True(t, errors.As(err, &httpErr))
+	assert.True(t, strings.HasPrefix(string(httpErr.Body), "upstream unavailable"))
+	assert.Len(t, httpErr.Body, MaxErrorBodyBytes)
+}
+
+func TestClient_Subscribe_FromTime(t *testing.T) {
+	fromTime := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	// 1. Set up a test server ignoring the fromTime parameter
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("lastEventId") == "" {
+			assert.Equal(t, "2021-03-01T12:00:00Z", r.URL.Query().Get("fromTime"))
+			fmt.Fprintln(w, `[{"id":"1","time":"2021-02-01T00:00:00Z"},{"id":"2","time":"2021-03-01T12:00:00Z"}]`)
+			return
+		}
+
+		assert.False(t, r.URL.Query().Has("fromTime"))
+		if r.URL.Query().Get("lastEventId") == "2" {
+			fmt.Fprintln(w, `[{"id":"3","time":"2021-03-02T00:00:00Z"}]`)
+			return
+		}
+		fmt.Fprintln(w, `[]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{
+		PollDelay: 10 * time.Millisecond,
+		FromTime:  fromTime,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	events := make(chan Event)
+	go func() {
+		_ = client.Subscribe(ts.URL, "", events, ctx)
+	}()
+
+	// 2. Expect the events before FromTime to be skipped
+	e := <-events
+	assert.Equal(t, "2", e.ID)
+	assert.Equal(t, ts.URL, e.Endpoint)
+	assert.Equal(t, "3", (<-events).ID)
+}
+
+func TestClient_Subscribe_FromNow(t *testing.T) {
+	store := NewInMemoryStore(InMemoryStoreOptions{})
+	store.Append(Event{}, Event{}, Event{})
+
+	ts := httptest.NewServer(NewFeedHandler(store, FeedHandlerOptions{BatchSize: 2}))
+	defer ts.Close()
+
+	events := make(chan Event)
+	client := NewClient(ClientOptions{PollDelay: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	go func() {
+		_ = client.Subscribe(ts.URL, SubscribeFromNow, events, ctx)
+	}()
+
+	// Expect only events appended after subscribing
+	time.Sleep(50 * time.Millisecond)
+	store.Append(Event{})
+
+	ev := <-events
+	assert.Equal(t, "4", ev.ID)
+}
+
+func TestClient_Subscribe_FromNowWithoutLatestEventId(t *testing.T) {
+	// 1. Set up a test server whose store doesn't report the latest event
+	store := &sliceStore{}
+	store.append(Event{ID: "1"}, Event{ID: "2"}, Event{ID: "3"})
+
+	ts := httptest.NewServer(NewFeedHandler(store, FeedHandlerOptions{BatchSize: 2}))
+	defer ts.Close()
+
+	events := make(chan Event)
+	client := NewClient(ClientOptions{PollDelay: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	go func() {
+		_ = client.Subscribe(ts.URL, SubscribeFromNow, events, ctx)
+	}()
+
+	// 2. Expect the feed to be read to its end before subscribing
+	time.Sleep(50 * time.Millisecond)
+	store.append(Event{ID: "4"})
+
+	assert.Equal(t, "4", (<-events).ID)
+}
+
+func TestClient_Subscribe_FromNowLatestEventIdHeader(t *testing.T) {
+	polls := make(chan string, 10)
+
+	// 1. Set up a paginated test server reporting the latest event
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastEventId := r.URL.Query().Get("lastEventId")
+		polls <- lastEventId
+
+		w.Header().Set(LatestEventIdHeader, "5")
+		switch lastEventId {
+		case "":
+			w.Header().Set("Link", fmt.Sprintf(`<%s?lastEventId=2>; rel="next"`, ts.URL))
+			fmt.Fprintln(w, `[{"id":"1"},{"id":"2"}]`)
+		case "2":
+			fmt.Fprintln(w, `[{"id":"3"},{"id":"4"},{"id":"5"}]`)
+		case "5":
+			fmt.Fprintln(w, `[{"id":"6"}]`)
+		default:
+			fmt.Fprintln(w, `[]`)
+		}
+	}))
+	defer ts.Close()
+
+	events := make(chan Event)
+	client := NewClient(ClientOptions{
+		PollDelay:       10 * time.Millisecond,
+		FollowNextLinks: true,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	go func() {
+		_ = client.Subscribe(ts.URL, SubscribeFromNow, events, ctx)
+	}()
+
+	// 2. Expect the subscription to start at the reported event after a single request
+	assert.Equal(t, "6", (<-events).ID)
+	assert.Equal(t, "", <-polls)
+	assert.Equal(t, "5", <-polls)
+}
+
+func TestClient_Subscribe_FromNowRetry(t *testing.T) {
+	var requests atomic.Int32
+
+	// 1. Set up a test server which fails the first request
+	store := NewInMemoryStore(InMemoryStoreOptions{})
+	store.Append(Event{}, Event{})
+	handler := NewFeedHandler(store, FeedHandlerOptions{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	defer ts.Close()
+
+	var errs atomic.Int32
+	events := make(chan Event)
+	client := NewClient(ClientOptions{
+		PollDelay:    10 * time.Millisecond,
+		ErrorHandler: func(error) { errs.Add(1) },
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	go func() {
+		_ = client.Subscribe(ts.URL, SubscribeFromNow, events, ctx)
+	}()
+
+	// 2. Expect the error to be reported and retried instead of ending the subscription
+	assert.Eventually(t, func() bool {
+		return requests.Load() >= 3
+	}, time.Second, 5*time.Millisecond)
+	store.Append(Event{})
+
+	assert.Equal(t, "3", (<-events).ID)
+	assert.Equal(t, int32(1), errs.Load())
+}
+
+func TestClient_Subscribe_FromNowUnsupportedTransport(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// Expect the subscription to fail right away instead of waiting for the end of the stream
+	for _, transport := range []Transport{TransportSSE, TransportWebSocket, TransportStream} {
+		client := NewClient(ClientOptions{Transport: transport})
+		err := client.Subscribe("http://localhost", SubscribeFromNow, make(chan Event), ctx)
+		assert.True(t, errors.Is(err, ErrSubscribeFromNowUnsupported), err)
+	}
+}
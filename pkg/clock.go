@@ -0,0 +1,45 @@
+package pkg
+
+import "time"
+
+// Clock provides the time to the polling of subscriptions, i.e. the poll delays, retries, the startup jitter and
+// MaxDuration. Replace it with a fake clock to test timing behavior without waiting. Implementations must be safe
+// for concurrent use, as they are shared by all subscriptions of a Client.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// NewTicker returns a Ticker that ticks every d.
+	NewTicker(d time.Duration) Ticker
+
+	// After returns a channel that receives the current time once d has elapsed.
+	After(d time.Duration) <-chan time.Time
+}
+
+// Ticker delivers ticks at intervals like a time.Ticker, see Clock.
+type Ticker interface {
+	// C returns the channel on which the ticks are delivered.
+	C() <-chan time.Time
+
+	// Reset stops the ticker and resets its period to d.
+	Reset(d time.Duration)
+
+	// Stop turns off the ticker.
+	Stop()
+}
+
+// realClock is the default Clock, using the time package.
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) NewTicker(d time.Duration) Ticker       { return realTicker{time.NewTicker(d)} }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// realTicker is a Ticker backed by a time.Ticker.
+type realTicker struct {
+	*time.Ticker
+}
+
+func (t realTicker) C() <-chan time.Time {
+	return t.Ticker.C
+}
@@ -0,0 +1,193 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClock is a Clock whose time only moves on Advance.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	tickers []*fakeTicker
+	timers  []fakeTimer
+}
+
+type fakeTimer struct {
+	at time.Time
+	c  chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *fakeClock) NewTicker(d time.Duration) Ticker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &fakeTicker{clock: c, c: make(chan time.Time, 1), period: d, next: c.now.Add(d)}
+	c.tickers = append(c.tickers, t)
+	return t
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	timer := fakeTimer{at: c.now.Add(d), c: make(chan time.Time, 1)}
+	c.timers = append(c.timers, timer)
+	return timer.c
+}
+
+// Advance moves the time forward by d and fires the tickers and timers which are due.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+	for _, t := range c.tickers {
+		if t.stopped || t.next.After(c.now) {
+			continue
+		}
+
+		// Like a time.Ticker, drop the ticks the receiver isn't ready for
+		select {
+		case t.c <- c.now:
+		default:
+		}
+		for !t.next.After(c.now) {
+			t.next = t.next.Add(t.period)
+		}
+	}
+
+	pending := c.timers[:0]
+	for _, timer := range c.timers {
+		if timer.at.After(c.now) {
+			pending = append(pending, timer)
+			continue
+		}
+		timer.c <- c.now
+	}
+	c.timers = pending
+}
+
+type fakeTicker struct {
+	clock   *fakeClock
+	c       chan time.Time
+	period  time.Duration
+	next    time.Time
+	stopped bool
+}
+
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTicker) Reset(d time.Duration) {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	t.period, t.next, t.stopped = d, t.clock.now.Add(d), false
+}
+
+func (t *fakeTicker) Stop() {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	t.stopped = true
+}
+
+func TestClient_Subscribe_Clock(t *testing.T) {
+	var requests atomic.Int32
+
+	// 1. Set up a test server
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		fmt.Fprintln(w, `[]`)
+	}))
+	defer ts.Close()
+
+	clock := newFakeClock()
+	client := NewClient(ClientOptions{
+		PollDelay: time.Hour,
+		Clock:     clock,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	go func() {
+		_ = client.Subscribe(ts.URL, "", make(chan Event), ctx)
+	}()
+
+	// 2. Expect the next poll only once the poll delay has passed on the clock
+	assert.Eventually(t, func() bool {
+		return requests.Load() == 1
+	}, time.Second, 5*time.Millisecond)
+
+	clock.Advance(time.Minute)
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, int32(1), requests.Load())
+
+	assert.Eventually(t, func() bool {
+		clock.Advance(time.Hour)
+		return requests.Load() >= 2
+	}, time.Second, 5*time.Millisecond)
+
+	stats := client.Stats()
+	assert.False(t, stats.LastSuccessfulPoll.Before(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, stats.LastSuccessfulPoll.Before(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestClient_Subscribe_ClockMaxDuration(t *testing.T) {
+	// 1. Set up a test server
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `[]`)
+	}))
+	defer ts.Close()
+
+	clock := newFakeClock()
+	client := NewClient(ClientOptions{
+		PollDelay:   time.Hour,
+		MaxDuration: 24 * time.Hour,
+		Clock:       clock,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	result := make(chan error, 1)
+	go func() {
+		result <- client.Subscribe(ts.URL, "", make(chan Event), ctx)
+	}()
+
+	// 2. Expect the subscription to end once the maximum duration has passed on the clock
+	var err error
+	assert.Eventually(t, func() bool {
+		clock.Advance(time.Hour)
+		select {
+		case err = <-result:
+			return true
+		default:
+			return false
+		}
+	}, time.Second, 5*time.Millisecond)
+	assert.True(t, errors.Is(err, ErrMaxDurationElapsed))
+}
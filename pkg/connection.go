@@ -0,0 +1,55 @@
+package pkg
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ConnectionOptions tunes the connection pool of the HTTP client the Client creates if no HTTPClient is set.
+// Long-polling and frequent simple polling both benefit from keeping the connections to the feed server open.
+type ConnectionOptions struct {
+	// MaxIdleConns limits the number of idle connections to all hosts. Defaults to the limit of http.DefaultTransport.
+	MaxIdleConns int
+
+	// MaxIdleConnsPerHost limits the number of idle connections per host. Defaults to the limit of
+	// http.DefaultTransport. Raise it when subscribing to many feeds of the same host.
+	MaxIdleConnsPerHost int
+
+	// IdleConnTimeout is how long an idle connection is kept open. Defaults to the timeout of http.DefaultTransport.
+	IdleConnTimeout time.Duration
+
+	// ForceHTTP2 attempts HTTP/2 even if a custom TLSConfig is set.
+	ForceHTTP2 bool
+}
+
+func (o ConnectionOptions) isZero() bool {
+	return o == ConnectionOptions{}
+}
+
+// newHTTPClient creates an HTTP client with a transport based on http.DefaultTransport, using the given TLS, proxy
+// and connection options.
+func newHTTPClient(tlsConfig *tls.Config, proxy func(*http.Request) (*url.URL, error), opts ConnectionOptions) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig.Clone()
+	}
+	if proxy != nil {
+		transport.Proxy = proxy
+	}
+	if opts.MaxIdleConns > 0 {
+		transport.MaxIdleConns = opts.MaxIdleConns
+	}
+	if opts.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = opts.MaxIdleConnsPerHost
+	}
+	if opts.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = opts.IdleConnTimeout
+	}
+	if opts.ForceHTTP2 {
+		transport.ForceAttemptHTTP2 = true
+	}
+
+	return &http.Client{Transport: transport}
+}
@@ -0,0 +1,144 @@
+package pkg
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newCountingServer starts a test server which counts the connections opened by the clients.
+func newCountingServer(handler http.HandlerFunc) (*httptest.Server, *int32) {
+	var conns int32
+	ts := httptest.NewUnstartedServer(handler)
+	ts.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&conns, 1)
+		}
+	}
+	ts.Start()
+
+	return ts, &conns
+}
+
+func TestClient_fetchEvents_ReusesConnections(t *testing.T) {
+	// 1. Set up a test server counting the connections
+	ts, conns := newCountingServer(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `[{"id":"1"}]`)
+	})
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{
+		Connection: ConnectionOptions{MaxIdleConnsPerHost: 4, IdleConnTimeout: time.Minute},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Expect all polls to share one connection
+	for i := 0; i < 10; i++ {
+		_, err := client.fetchEvents(ts.URL, "", ctx)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(conns))
+}
+
+func BenchmarkClient_fetchEvents(b *testing.B) {
+	ts, conns := newCountingServer(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `[{"id":"1","type":"t"},{"id":"2","type":"t"}]`)
+	})
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{})
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := client.fetchEvents(ts.URL, "", ctx); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.StopTimer()
+
+	b.ReportMetric(float64(atomic.LoadInt32(conns)), "conns")
+}
+
+// newProxy starts a forward proxy which records the proxied requests. Plain HTTP requests are answered directly with
+// an event, CONNECT requests are tunneled to the target.
+func newProxy(t *testing.T, proxied chan<- string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied <- r.Method + " " + r.Host
+
+		if r.Method != http.MethodConnect {
+			assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+			fmt.Fprintln(w, `[{"id":"proxied"}]`)
+			return
+		}
+
+		target, err := net.Dial("tcp", r.Host)
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer target.Close()
+
+		w.WriteHeader(http.StatusOK)
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer conn.Close()
+
+		go func() { _, _ = io.Copy(target, conn) }()
+		_, _ = io.Copy(conn, target)
+	}))
+}
+
+func TestClient_fetchEvents_Proxy(t *testing.T) {
+	proxied := make(chan string, 10)
+	proxy := newProxy(t, proxied)
+	defer proxy.Close()
+	proxyURL, _ := url.Parse(proxy.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 1. Expect plain HTTP requests to be sent to the proxy
+	client := NewClient(ClientOptions{
+		AuthToken: "token",
+		Proxy:     http.ProxyURL(proxyURL),
+	})
+	events, err := client.fetchEvents("http://feed.invalid/events", "", ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "proxied", events[0].ID)
+	assert.Equal(t, "GET feed.invalid", <-proxied)
+
+	// 2. Expect TLS requests to be tunneled through the proxy
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		fmt.Fprintln(w, `[{"id":"tunneled"}]`)
+	}))
+	defer ts.Close()
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(ts.Certificate())
+
+	client = NewClient(ClientOptions{
+		AuthToken: "token",
+		Proxy:     http.ProxyURL(proxyURL),
+		TLSConfig: &tls.Config{RootCAs: rootCAs},
+	})
+	events, err = client.fetchEvents(ts.URL, "", ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "tunneled", events[0].ID)
+	assert.Equal(t, "CONNECT "+ts.Listener.Addr().String(), <-proxied)
+}
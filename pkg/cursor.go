@@ -0,0 +1,65 @@
+package pkg
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Cursor persists the ID of the last event delivered by a subscription, so that it can be resumed after a restart.
+type Cursor interface {
+	// Load returns the stored event ID, or an empty string if none has been stored yet.
+	Load() (string, error)
+
+	// Save stores the ID of the last delivered event.
+	Save(id string) error
+}
+
+// FileCursor is a Cursor that stores the event ID in a file.
+type FileCursor struct {
+	path string
+}
+
+// NewFileCursor creates a FileCursor that stores the event ID at path. The file is created on the first Save.
+func NewFileCursor(path string) *FileCursor {
+	return &FileCursor{path: path}
+}
+
+// Load reads the event ID from the file. A missing file is not an error and yields an empty ID.
+func (c *FileCursor) Load() (string, error) {
+	b, err := os.ReadFile(c.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(b)), nil
+}
+
+// Save writes the event ID to the file. The file is replaced atomically, so a crash never leaves a partial ID behind.
+func (c *FileCursor) Save(id string) error {
+	return writeFileAtomic(c.path, []byte(id))
+}
+
+// writeFileAtomic writes b to a temporary file first and then renames it to path.
+func writeFileAtomic(path string, b []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
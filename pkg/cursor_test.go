@@ -0,0 +1,72 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileCursor(t *testing.T) {
+	cursor := NewFileCursor(filepath.Join(t.TempDir(), "cursor"))
+
+	// 1. Loading a missing file yields an empty ID
+	id, err := cursor.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, "", id)
+
+	// 2. Saved IDs are loaded again
+	assert.NoError(t, cursor.Save("1"))
+	assert.NoError(t, cursor.Save("2"))
+
+	id, err = cursor.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, "2", id)
+}
+
+func TestClient_Subscribe_Cursor(t *testing.T) {
+	var lastEventIdQueryValue string
+
+	// 1. Setup a test server
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastEventIdQueryValue = r.URL.Query().Get("lastEventId")
+		if lastEventIdQueryValue == "5" {
+			fmt.Fprintln(w, `[{"id":"6"}]`)
+			return
+		}
+
+		fmt.Fprintln(w, `[]`)
+	}))
+	defer ts.Close()
+
+	cursor := NewFileCursor(filepath.Join(t.TempDir(), "cursor"))
+	assert.NoError(t, cursor.Save("5"))
+
+	events := make(chan Event)
+	client := NewClient(ClientOptions{
+		PollDelay: 10 * time.Millisecond,
+		Cursor:    cursor,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	go func() {
+		_ = client.Subscribe(ts.URL, "1", events, ctx)
+	}()
+
+	// 2. Expect the subscription to resume from the stored cursor
+	ev := <-events
+	assert.Equal(t, "6", ev.ID)
+
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		id, err := cursor.Load()
+		assert.NoError(c, err)
+		assert.Equal(c, "6", id)
+	}, 1*time.Second, 10*time.Millisecond)
+}
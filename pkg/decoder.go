@@ -0,0 +1,65 @@
+package pkg
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Decoder decodes JSON. Implement it to decode the responses with a faster JSON library than encoding/json. The
+// Unmarshal functions of most libraries, like jsoniter or sonic, satisfy it with a small adapter:
+//
+//	type jsoniterDecoder struct{}
+//
+//	func (jsoniterDecoder) Unmarshal(data []byte, v interface{}) error {
+//		return jsoniter.ConfigCompatibleWithStandardLibrary.Unmarshal(data, v)
+//	}
+//
+// Implementations must be compatible with encoding/json, i.e. respect the struct tags and json.RawMessage, and be
+// safe for concurrent use.
+type Decoder interface {
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// DecoderFunc is a function that satisfies Decoder.
+type DecoderFunc func(data []byte, v interface{}) error
+
+func (f DecoderFunc) Unmarshal(data []byte, v interface{}) error {
+	return f(data, v)
+}
+
+// DefaultDecoder decodes JSON with encoding/json.
+var DefaultDecoder Decoder = DecoderFunc(json.Unmarshal)
+
+// decodeOptions configure the decoding of the events of a response.
+type decodeOptions struct {
+	decoder Decoder
+
+	// skip receives the errors of events which can't be decoded. If nil, such events fail the whole response.
+	skip func(error)
+
+	// keepRaw keeps the original JSON of each event in Event.Raw.
+	keepRaw bool
+}
+
+// decodeOptions returns the options for decoding the events of a response to a request with ctx.
+func (c *Client) decodeOptions(ctx context.Context) decodeOptions {
+	opts := decodeOptions{decoder: c.decoder, keepRaw: c.keepRawEvents}
+	if c.skipInvalidEvents {
+		opts.skip = func(err error) { c.handleError(err, ctx) }
+	}
+
+	return opts
+}
+
+// decode decodes a single event from b.
+func (opts decodeOptions) decode(b []byte) (Event, error) {
+	var e Event
+	if err := decodeEvent(b, &e, opts.decoder); err != nil {
+		return Event{}, err
+	}
+	if opts.keepRaw {
+		e.Raw = b
+	}
+
+	return e, nil
+}
@@ -0,0 +1,75 @@
+package pkg
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+)
+
+var jsoniterDecoder = DecoderFunc(jsoniter.ConfigCompatibleWithStandardLibrary.Unmarshal)
+
+func TestClient_fetchEvents_Decoder(t *testing.T) {
+	// 1. Set up a test server
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `[{"id":"1","time":"2021-03-01 12:00:00","sequence":1,"data":{"sku":"a"}}]`)
+	}))
+	defer ts.Close()
+
+	var calls int32
+	client := NewClient(ClientOptions{
+		Decoder: DecoderFunc(func(data []byte, v interface{}) error {
+			atomic.AddInt32(&calls, 1)
+			return jsoniterDecoder.Unmarshal(data, v)
+		}),
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Expect the events to be decoded with the custom decoder like with the default one
+	events, err := client.fetchEvents(ts.URL, "", ctx)
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, "1", events[0].ID)
+	assert.Equal(t, time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC), events[0].Time)
+	assert.Equal(t, map[string]interface{}{"sequence": float64(1)}, events[0].Extensions)
+	assert.Equal(t, "a", events[0].DataMap()["sku"])
+	assert.Greater(t, atomic.LoadInt32(&calls), int32(0))
+}
+
+func BenchmarkDecodeEvents(b *testing.B) {
+	var body bytes.Buffer
+	body.WriteString("[")
+	for i := 0; i < 1000; i++ {
+		if i > 0 {
+			body.WriteString(",")
+		}
+		fmt.Fprintf(&body, `{"specversion":"1.0","id":"%d","type":"order.created","source":"/orders",`+
+			`"time":"2021-03-01T12:00:00Z","subject":"order-%d","data":{"sku":"a-%d","price":12.5,"tags":["x","y"]}}`, i, i, i)
+	}
+	body.WriteString("]")
+
+	decoders := map[string]Decoder{
+		"encoding/json": DefaultDecoder,
+		"jsoniter":      jsoniterDecoder,
+	}
+	for name, dec := range decoders {
+		b.Run(name, func(b *testing.B) {
+			b.SetBytes(int64(body.Len()))
+			for i := 0; i < b.N; i++ {
+				_, err := decodeEvents(bytes.NewReader(body.Bytes()), "", decodeOptions{decoder: dec}, func(Event) error { return nil })
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
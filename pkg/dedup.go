@@ -0,0 +1,46 @@
+package pkg
+
+import "container/list"
+
+const DefaultDeduplicationWindow = 1000
+
+// idCache is a bounded LRU set of event IDs used to detect duplicate deliveries.
+type idCache struct {
+	capacity int
+	order    *list.List
+	items    map[string]*list.Element
+}
+
+func newIDCache(capacity int) *idCache {
+	return &idCache{
+		capacity: capacity,
+		order:    list.New(),
+		items:    make(map[string]*list.Element, capacity),
+	}
+}
+
+// contains reports whether id has been added recently and marks it as recently used.
+func (c *idCache) contains(id string) bool {
+	el, ok := c.items[id]
+	if ok {
+		c.order.MoveToFront(el)
+	}
+
+	return ok
+}
+
+// add adds id to the cache, evicting the least recently used ID if the cache is full.
+func (c *idCache) add(id string) {
+	if el, ok := c.items[id]; ok {
+		c.order.MoveToFront(el)
+		return
+	}
+
+	c.items[id] = c.order.PushFront(id)
+
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(string))
+	}
+}
@@ -0,0 +1,58 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIDCache(t *testing.T) {
+	c := newIDCache(2)
+
+	c.add("1")
+	c.add("2")
+	assert.True(t, c.contains("1"))
+
+	// "2" is the least recently used ID and gets evicted
+	c.add("3")
+	assert.True(t, c.contains("1"))
+	assert.False(t, c.contains("2"))
+	assert.True(t, c.contains("3"))
+	assert.Len(t, c.items, 2)
+}
+
+func TestClient_Subscribe_Deduplicate(t *testing.T) {
+	// 1. Setup a test server that delivers the boundary event again
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("lastEventId") == "2" {
+			fmt.Fprintln(w, `[{"id":"2"},{"id":"3"}]`)
+			return
+		}
+
+		fmt.Fprintln(w, `[{"id":"1"},{"id":"2"}]`)
+	}))
+	defer ts.Close()
+
+	events := make(chan Event)
+	client := NewClient(ClientOptions{
+		PollDelay:   10 * time.Millisecond,
+		Deduplicate: true,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	go func() {
+		_ = client.Subscribe(ts.URL, "", events, ctx)
+	}()
+
+	// 2. Expect the duplicate to be skipped
+	assert.Equal(t, "1", (<-events).ID)
+	assert.Equal(t, "2", (<-events).ID)
+	assert.Equal(t, "3", (<-events).ID)
+}
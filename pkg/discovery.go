@@ -0,0 +1,103 @@
+package pkg
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DiscoveryPath is the well-known path of the document describing the feeds of a server.
+const DiscoveryPath = "/.well-known/http-feeds"
+
+// FeedInfo describes the capabilities of a feed server, as returned by Client.Discover.
+type FeedInfo struct {
+	// LongPolling tells whether the server supports long-polling.
+	LongPolling bool `json:"longPolling"`
+
+	// MaxTimeout is the longest long-polling timeout in milliseconds the server accepts.
+	MaxTimeout int64 `json:"maxTimeout,omitempty"`
+
+	// MediaTypes are the media types the server can respond with.
+	MediaTypes []string `json:"mediaTypes,omitempty"`
+
+	// BatchSize is the maximum number of events per response.
+	BatchSize int `json:"batchSize,omitempty"`
+
+	// Compression tells whether the server supports gzip compressed responses.
+	Compression bool `json:"compression,omitempty"`
+
+	// LiveLinks tells whether the feeds of the server are split into an archive and a live feed, which the last page
+	// of the archive links to with rel="live".
+	LiveLinks bool `json:"liveLinks,omitempty"`
+}
+
+// Apply returns opts with the options which are not set yet configured for the capabilities of the server, i.e.
+// long-polling, compression, the batch size, the accepted media types and following live links. Options which are
+// already set are kept.
+func (info FeedInfo) Apply(opts ClientOptions) ClientOptions {
+	if info.LongPolling && opts.Timeout == 0 {
+		opts.Timeout = DefaultMaxLongPollTimeout
+		if maxTimeout := time.Duration(info.MaxTimeout) * time.Millisecond; maxTimeout > 0 && maxTimeout < opts.Timeout {
+			opts.Timeout = maxTimeout
+		}
+	}
+	if info.Compression {
+		opts.EnableCompression = true
+	}
+	if info.LiveLinks {
+		opts.FollowLiveLinks = true
+	}
+	if opts.BatchSize == 0 {
+		opts.BatchSize = info.BatchSize
+	}
+	if opts.Accept == "" && len(info.MediaTypes) > 0 {
+		opts.Accept = strings.Join(info.MediaTypes, ", ")
+	}
+
+	return opts
+}
+
+// Discover fetches the capabilities of the server of the feed at endpoint from the well-known DiscoveryPath of the
+// server. Apply the returned FeedInfo to the ClientOptions to create a client matching the server. Servers without
+// the document respond with an error response, which is returned as a FeedHTTPError.
+func (c *Client) Discover(endpoint string, ctx context.Context) (FeedInfo, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return FeedInfo{}, err
+	}
+	u = u.ResolveReference(&url.URL{Path: DiscoveryPath})
+
+	if c.requestTimeout != 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.requestTimeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, withoutUserinfo(u).String(), nil)
+	if err != nil {
+		return FeedInfo{}, err
+	}
+	c.addHeaders(req.Header, u, ctx)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return FeedInfo{}, err
+	}
+	defer drainBody(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return FeedInfo{}, responseError(resp, resp.Body, c.clock.Now())
+	}
+
+	var info FeedInfo
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return FeedInfo{}, fmt.Errorf("could not decode feed info: %w", err)
+	}
+
+	return info, nil
+}
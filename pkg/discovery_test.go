@@ -0,0 +1,60 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_Discover(t *testing.T) {
+	// 1. Set up a test server describing its feeds
+	mux := http.NewServeMux()
+	mux.HandleFunc(DiscoveryPath, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		fmt.Fprintln(w, `{"longPolling":true,"maxTimeout":10000,"mediaTypes":["application/json"],"batchSize":50,"compression":true,"liveLinks":true}`)
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{AuthToken: "token"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Expect the capabilities of the server
+	info, err := client.Discover(ts.URL+"/feeds/orders?x=1", ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, FeedInfo{
+		LongPolling: true,
+		MaxTimeout:  10000,
+		MediaTypes:  []string{"application/json"},
+		BatchSize:   50,
+		Compression: true,
+		LiveLinks:   true,
+	}, info)
+
+	// 3. Expect the capabilities to configure the options which are not set
+	opts := info.Apply(ClientOptions{BatchSize: 10})
+	assert.Equal(t, 10*time.Second, opts.Timeout)
+	assert.True(t, opts.EnableCompression)
+	assert.True(t, opts.FollowLiveLinks)
+	assert.Equal(t, 10, opts.BatchSize)
+	assert.Equal(t, "application/json", opts.Accept)
+}
+
+func TestClient_Discover_NotSupported(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	defer ts.Close()
+
+	_, err := NewClient(ClientOptions{}).Discover(ts.URL, context.Background())
+
+	var httpErr *FeedHTTPError
+	assert.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, http.StatusNotFound, httpErr.StatusCode)
+}
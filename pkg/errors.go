@@ -0,0 +1,30 @@
+package pkg
+
+import (
+	"fmt"
+	"time"
+)
+
+// FeedHTTPError is returned when the server responds to a poll with an unexpected status code.
+// Use errors.As to inspect the status code, e.g. to decide whether to keep retrying.
+type FeedHTTPError struct {
+	// StatusCode is the HTTP status code of the response, e.g. 503.
+	StatusCode int
+
+	// Status is the HTTP status line of the response, e.g. "503 Service Unavailable".
+	Status string
+
+	// Body is the body of the response, if the server sent one, truncated to MaxErrorBodyBytes.
+	Body []byte
+
+	// RetryAfter is the delay requested by the Retry-After header of a 429 or 503 response. Zero if not set.
+	RetryAfter time.Duration
+}
+
+func (e *FeedHTTPError) Error() string {
+	if len(e.Body) > 0 {
+		return fmt.Sprintf("got error response from server. status: %s, body: %s", e.Status, e.Body)
+	}
+
+	return fmt.Sprintf("got error response from server. status: %s", e.Status)
+}
@@ -1,9 +1,28 @@
 package pkg
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
 	"time"
 )
 
+// MediaTypeCloudEventsBatch is the media type of a feed response containing a JSON array of events.
+const MediaTypeCloudEventsBatch = "application/cloudevents-batch+json"
+
+// FeedMediaTypes are the media types of feed responses defined by the HTTP feeds specification.
+var FeedMediaTypes = []string{MediaTypeCloudEventsBatch, "application/json"}
+
+// ErrUnsupportedDataContentType is returned when the data of an event can't be decoded because of its content type.
+var ErrUnsupportedDataContentType = errors.New("unsupported data content type")
+
+// ErrInvalidEvent is returned when an event is missing required CloudEvents attributes.
+var ErrInvalidEvent = errors.New("invalid event")
+
 // Event represents a CloudEvent. See  https://github.com/cloudevents/spec
 type Event struct {
 	SpecVersion     string                 `json:"specversion"`               // The currently supported CloudEvents specification version.
@@ -14,5 +33,254 @@ type Event struct {
 	Subject         string                 `json:"subject"`                   // Key to identify the business object.
 	Method          string                 `json:"method,omitempty"`          // The HTTP equivalent method type that the feed item performs on the subject. Defaults to PUT.
 	DataContentType string                 `json:"datacontenttype,omitempty"` // Defaults to application/json.
-	Data            map[string]interface{} `json:"data,omitempty"`            // The payload of the item.
+	Data            interface{}            `json:"data,omitempty"`            // The payload of the item, any JSON value. Objects are decoded as map[string]interface{}, see DataMap.
+	DataBase64      string                 `json:"data_base64,omitempty"`     // A binary payload of the item, encoded as base64 instead of Data. See DataBytes.
+	Extensions      map[string]interface{} `json:"-"`                         // Extension attributes, i.e. all top-level attributes not listed above.
+	Endpoint        string                 `json:"-"`                         // The feed endpoint the event was received from. Set by the Client.
+	Raw             json.RawMessage        `json:"-"`                         // The original JSON of the event. Only set with ClientOptions.KeepRawEvents.
+	Cached          bool                   `json:"-"`                         // Whether the event is delivered again from the ClientOptions.TailCache.
+
+	rawTime string // The time attribute as received, see RawTime.
+}
+
+// eventAttributes are the attributes decoded into the fields of Event, all other attributes are extensions.
+var eventAttributes = map[string]bool{
+	"specversion":     true,
+	"id":              true,
+	"type":            true,
+	"source":          true,
+	"time":            true,
+	"subject":         true,
+	"method":          true,
+	"datacontenttype": true,
+	"data":            true,
+	"data_base64":     true,
+}
+
+// timeLayouts are the layouts tried when decoding the time attribute, starting with the RFC 3339 layout required by
+// the specification. Layouts without a time zone are interpreted as UTC.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z0700",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// UnmarshalJSON decodes an event. The time attribute is parsed leniently, accepting common deviations from RFC 3339
+// like a missing time zone or a space as separator. If the time can't be parsed, Time is left zero instead of failing
+// the decoding, and the original value is available from RawTime. Unknown attributes are decoded into Extensions.
+func (e *Event) UnmarshalJSON(b []byte) error {
+	return decodeEvent(b, e, DefaultDecoder)
+}
+
+// decodeEvent decodes an event like Event.UnmarshalJSON, using dec for decoding the JSON.
+func decodeEvent(b []byte, e *Event, dec Decoder) error {
+	type event Event // prevents the recursion into UnmarshalJSON
+	aux := struct {
+		*event
+		Time json.RawMessage `json:"time"`
+	}{event: (*event)(e)}
+
+	if err := dec.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+
+	e.Time = time.Time{}
+	e.rawTime = ""
+	if len(aux.Time) > 0 && string(aux.Time) != "null" {
+		// Keep values which aren't even a string, like numbers, as they are
+		if err := dec.Unmarshal(aux.Time, &e.rawTime); err != nil {
+			e.rawTime = string(aux.Time)
+		}
+		e.Time, _ = parseTime(e.rawTime)
+	}
+
+	var attributes map[string]json.RawMessage
+	if err := dec.Unmarshal(b, &attributes); err != nil {
+		return err
+	}
+
+	e.Extensions = nil
+	for name, raw := range attributes {
+		if eventAttributes[name] {
+			continue
+		}
+
+		var value interface{}
+		if err := dec.Unmarshal(raw, &value); err != nil {
+			return fmt.Errorf("could not decode extension attribute %q: %w", name, err)
+		}
+		if e.Extensions == nil {
+			e.Extensions = make(map[string]interface{})
+		}
+		e.Extensions[name] = value
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes an event, adding the Extensions as top-level attributes. Extensions can't override the
+// attributes of the Event fields. A time that couldn't be parsed is encoded as it was received, see RawTime, and a
+// zero time is omitted.
+func (e Event) MarshalJSON() ([]byte, error) {
+	type event Event // prevents the recursion into MarshalJSON
+	aux := struct {
+		event
+		Time interface{} `json:"time,omitempty"`
+	}{event: event(e)}
+	switch {
+	case !e.Time.IsZero():
+		aux.Time = e.Time
+	case e.rawTime != "":
+		aux.Time = e.rawTime
+	}
+
+	b, err := json.Marshal(aux)
+	if err != nil || len(e.Extensions) == 0 {
+		return b, err
+	}
+
+	var attributes map[string]json.RawMessage
+	if err := json.Unmarshal(b, &attributes); err != nil {
+		return nil, err
+	}
+
+	for name, value := range e.Extensions {
+		if eventAttributes[name] {
+			continue
+		}
+
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("could not encode extension attribute %q: %w", name, err)
+		}
+		attributes[name] = raw
+	}
+
+	return json.Marshal(attributes)
+}
+
+// RawTime returns the time attribute as it was received. Use it to access times that could not be parsed, in which
+// case Time is zero.
+func (e Event) RawTime() string {
+	return e.rawTime
+}
+
+func parseTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unsupported time format: %q", value)
+}
+
+// Validate checks that the required CloudEvents attributes id, specversion, type and source are set.
+// The returned error wraps ErrInvalidEvent.
+func (e Event) Validate() error {
+	var missing []string
+	if e.ID == "" {
+		missing = append(missing, "id")
+	}
+	if e.SpecVersion == "" {
+		missing = append(missing, "specversion")
+	}
+	if e.Type == "" {
+		missing = append(missing, "type")
+	}
+	if e.Source == "" {
+		missing = append(missing, "source")
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("%w %q: missing required attributes: %s", ErrInvalidEvent, e.ID, strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// EffectiveMethod returns the HTTP method the event performs on its subject, applying the default of PUT when
+// Method is not set.
+func (e Event) EffectiveMethod() string {
+	if e.Method == "" {
+		return http.MethodPut
+	}
+
+	return strings.ToUpper(e.Method)
+}
+
+// IsDelete reports whether the event signals the removal of its subject. Data may be empty for such events.
+func (e Event) IsDelete() bool {
+	return e.EffectiveMethod() == http.MethodDelete
+}
+
+// DataMap returns the data of the event if it is a JSON object, which is the common case, or nil otherwise. It
+// replaces indexing Data directly, i.e. e.Data["sku"] becomes e.DataMap()["sku"].
+func (e Event) DataMap() map[string]interface{} {
+	m, _ := e.Data.(map[string]interface{})
+	return m
+}
+
+// DataBytes returns the payload of the event as bytes, honoring its DataContentType: the decoded DataBase64 of binary
+// payloads, the text of non-JSON payloads like XML or plain text as it is, and the JSON encoding of JSON payloads.
+// Returns nil for events without data.
+func (e Event) DataBytes() ([]byte, error) {
+	if e.DataBase64 != "" {
+		b, err := base64.StdEncoding.DecodeString(e.DataBase64)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode data_base64 of event %q: %w", e.ID, err)
+		}
+		return b, nil
+	}
+	if e.Data == nil {
+		return nil, nil
+	}
+
+	// Non-JSON payloads are carried as a JSON string
+	if text, ok := e.Data.(string); ok && !isJSONContentType(e.DataContentType) {
+		return []byte(text), nil
+	}
+
+	return json.Marshal(e.Data)
+}
+
+// UnmarshalData decodes the data of the event into a value of type T.
+// Only JSON data is supported, i.e. an empty DataContentType, application/json or any +json media type.
+// For other content types an error wrapping ErrUnsupportedDataContentType is returned.
+func UnmarshalData[T any](e Event) (T, error) {
+	var v T
+
+	if !isJSONContentType(e.DataContentType) {
+		return v, fmt.Errorf("%w: %s", ErrUnsupportedDataContentType, e.DataContentType)
+	}
+
+	b, err := json.Marshal(e.Data)
+	if err != nil {
+		return v, err
+	}
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return v, err
+	}
+
+	return v, nil
+}
+
+// isJSONContentType reports whether contentType is a JSON media type. An empty content type defaults to JSON.
+func isJSONContentType(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
 }
@@ -0,0 +1,220 @@
+package pkg
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type product struct {
+	SKU   string  `json:"sku"`
+	Price float64 `json:"price"`
+}
+
+func TestUnmarshalData(t *testing.T) {
+	e := Event{
+		ID:   "1",
+		Data: map[string]interface{}{"sku": "abc", "price": 9.99},
+	}
+
+	p, err := UnmarshalData[product](e)
+	assert.NoError(t, err)
+	assert.Equal(t, product{SKU: "abc", Price: 9.99}, p)
+}
+
+func TestUnmarshalData_jsonContentTypes(t *testing.T) {
+	for _, contentType := range []string{"application/json", "application/json; charset=utf-8", "application/vnd.product+json"} {
+		e := Event{
+			DataContentType: contentType,
+			Data:            map[string]interface{}{"sku": "abc"},
+		}
+
+		p, err := UnmarshalData[product](e)
+		assert.NoError(t, err, contentType)
+		assert.Equal(t, "abc", p.SKU, contentType)
+	}
+}
+
+func TestUnmarshalData_unsupportedContentType(t *testing.T) {
+	e := Event{
+		DataContentType: "application/xml",
+		Data:            map[string]interface{}{"sku": "abc"},
+	}
+
+	_, err := UnmarshalData[product](e)
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, ErrUnsupportedDataContentType))
+}
+
+func TestEvent_EffectiveMethod(t *testing.T) {
+	assert.Equal(t, "PUT", Event{}.EffectiveMethod())
+	assert.Equal(t, "PUT", Event{Method: "PUT"}.EffectiveMethod())
+	assert.Equal(t, "DELETE", Event{Method: "delete"}.EffectiveMethod())
+}
+
+func TestEvent_IsDelete(t *testing.T) {
+	assert.False(t, Event{}.IsDelete())
+	assert.False(t, Event{Method: "PUT"}.IsDelete())
+	assert.True(t, Event{Method: "DELETE"}.IsDelete())
+	assert.True(t, Event{Method: "delete"}.IsDelete())
+}
+
+func TestEvent_Validate(t *testing.T) {
+	e := Event{SpecVersion: "1.0", ID: "1", Type: "product.updated", Source: "/products"}
+	assert.NoError(t, e.Validate())
+
+	err := Event{ID: "1", Type: "product.updated"}.Validate()
+	assert.True(t, errors.Is(err, ErrInvalidEvent))
+	assert.ErrorContains(t, err, "specversion, source")
+}
+
+func TestEvent_UnmarshalJSON_time(t *testing.T) {
+	tests := map[string]time.Time{
+		`"2024-01-02T03:04:05Z"`:           time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		`"2024-01-02T03:04:05.123+01:00"`:  time.Date(2024, 1, 2, 2, 4, 5, 123000000, time.UTC),
+		`"2024-01-02T03:04:05"`:            time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		`"2024-01-02 03:04:05"`:            time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		`"2024-01-02 03:04:05+0100"`:       time.Date(2024, 1, 2, 2, 4, 5, 0, time.UTC),
+		`"2024-01-02"`:                     time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		`"2024-01-02T03:04:05.999999999Z"`: time.Date(2024, 1, 2, 3, 4, 5, 999999999, time.UTC),
+	}
+
+	for value, expected := range tests {
+		var e Event
+		assert.NoError(t, json.Unmarshal([]byte(`{"id":"1","time":`+value+`}`), &e), value)
+		assert.True(t, expected.Equal(e.Time), "%s: got %s", value, e.Time)
+		assert.Equal(t, "1", e.ID)
+	}
+}
+
+func TestEvent_UnmarshalJSON_invalidTime(t *testing.T) {
+	var events []Event
+	err := json.Unmarshal([]byte(`[{"id":"1","time":"yesterday"},{"id":"2"},{"id":"3","time":1704164645}]`), &events)
+	assert.NoError(t, err)
+	assert.Len(t, events, 3)
+
+	assert.True(t, events[0].Time.IsZero())
+	assert.Equal(t, "yesterday", events[0].RawTime())
+	assert.Equal(t, "", events[1].RawTime())
+	assert.True(t, events[2].Time.IsZero())
+	assert.Equal(t, "1704164645", events[2].RawTime())
+}
+
+func TestEvent_UnmarshalJSON_extensions(t *testing.T) {
+	var e Event
+	err := json.Unmarshal([]byte(`{"id":"1","type":"t","sequence":42,"partitionkey":"p","data":{"a":1}}`), &e)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", e.ID)
+	assert.Equal(t, map[string]interface{}{"sequence": float64(42), "partitionkey": "p"}, e.Extensions)
+
+	err = json.Unmarshal([]byte(`{"id":"2"}`), &e)
+	assert.NoError(t, err)
+	assert.Nil(t, e.Extensions)
+}
+
+func TestEvent_UnmarshalJSON_nonObjectData(t *testing.T) {
+	var events []Event
+	err := json.Unmarshal([]byte(`[{"id":"1","data":{"sku":"a"}},{"id":"2","data":[1,2]},{"id":"3","data":"text"},{"id":"4","data":7}]`), &events)
+	assert.NoError(t, err)
+
+	// Any JSON value is accepted as data, DataMap only returns objects
+	assert.Equal(t, "a", events[0].DataMap()["sku"])
+	assert.Equal(t, []interface{}{float64(1), float64(2)}, events[1].Data)
+	assert.Nil(t, events[1].DataMap())
+	assert.Equal(t, "text", events[2].Data)
+	assert.Equal(t, float64(7), events[3].Data)
+
+	list, err := UnmarshalData[[]int](events[1])
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, list)
+}
+
+func TestEvent_DataBytes(t *testing.T) {
+	var events []Event
+	err := json.Unmarshal([]byte(`[
+		{"id":"1","datacontenttype":"application/xml","data":"<order id=\"1\"/>"},
+		{"id":"2","datacontenttype":"text/plain","data":"hello"},
+		{"id":"3","datacontenttype":"application/octet-stream","data_base64":"AQID"},
+		{"id":"4","data":{"sku":"a"}},
+		{"id":"5","datacontenttype":"application/json","data":"quoted"},
+		{"id":"6"},
+		{"id":"7","data_base64":"not base64!"}
+	]`), &events)
+	assert.NoError(t, err)
+
+	// Expect the payloads as bytes depending on the content type
+	for i, tc := range []struct {
+		want []byte
+		err  bool
+	}{
+		{want: []byte(`<order id="1"/>`)},
+		{want: []byte("hello")},
+		{want: []byte{1, 2, 3}},
+		{want: []byte(`{"sku":"a"}`)},
+		{want: []byte(`"quoted"`)},
+		{want: nil},
+		{err: true},
+	} {
+		b, err := events[i].DataBytes()
+		if tc.err {
+			assert.Error(t, err)
+		} else {
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, b, events[i].ID)
+		}
+	}
+}
+
+func TestEvent_MarshalJSON_dataBase64(t *testing.T) {
+	in := `{"id":"1","datacontenttype":"image/png","data_base64":"iVBORw0K"}`
+
+	var e Event
+	assert.NoError(t, json.Unmarshal([]byte(in), &e))
+	assert.Equal(t, "iVBORw0K", e.DataBase64)
+	assert.Nil(t, e.Extensions)
+
+	// Expect the binary payload to survive a round trip
+	b, err := json.Marshal(e)
+	assert.NoError(t, err)
+	assert.Contains(t, string(b), `"data_base64":"iVBORw0K"`)
+	assert.NotContains(t, string(b), `"data":`)
+}
+
+func TestEvent_MarshalJSON_rawTime(t *testing.T) {
+	in := `[{"id":"1","time":"yesterday"},{"id":"2"},{"id":"3","time":"2024-01-02T03:04:05Z"}]`
+
+	var events []Event
+	assert.NoError(t, json.Unmarshal([]byte(in), &events))
+
+	// Expect an unparseable time to survive a round trip, and a missing time to stay missing
+	b, err := json.Marshal(events)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(b), `"0001-01-01T00:00:00Z"`)
+
+	var out []Event
+	assert.NoError(t, json.Unmarshal(b, &out))
+	assert.Equal(t, "yesterday", out[0].RawTime())
+	assert.True(t, out[0].Time.IsZero())
+	assert.Equal(t, "", out[1].RawTime())
+	assert.Equal(t, events[2].Time, out[2].Time)
+}
+
+func TestEvent_MarshalJSON_extensions(t *testing.T) {
+	in := `{"id":"1","type":"t","sequence":42,"traceparent":"00-abc-def-01"}`
+
+	var e Event
+	assert.NoError(t, json.Unmarshal([]byte(in), &e))
+
+	// Expect the extensions to survive a round trip, without overriding the event attributes
+	e.Extensions["id"] = "other"
+	b, err := json.Marshal(e)
+	assert.NoError(t, err)
+
+	var out Event
+	assert.NoError(t, json.Unmarshal(b, &out))
+	assert.Equal(t, "1", out.ID)
+	assert.Equal(t, map[string]interface{}{"sequence": float64(42), "traceparent": "00-abc-def-01"}, out.Extensions)
+}
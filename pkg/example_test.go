@@ -0,0 +1,42 @@
+package pkg_test
+
+import (
+	"expvar"
+	"time"
+
+	"github.com/korve/go-http-feeds/pkg"
+)
+
+// expvarMetrics exports the metrics of a Client with the expvar package. An adapter for Prometheus or another
+// monitoring system looks the same, using counters and histograms instead.
+type expvarMetrics struct {
+	pollDuration   *expvar.Float
+	eventsReceived *expvar.Int
+	fetchErrors    *expvar.Int
+}
+
+func (m *expvarMetrics) ObservePollDuration(d time.Duration) {
+	m.pollDuration.Set(d.Seconds())
+}
+
+func (m *expvarMetrics) IncEventsReceived(n int) {
+	m.eventsReceived.Add(int64(n))
+}
+
+func (m *expvarMetrics) IncFetchError() {
+	m.fetchErrors.Add(1)
+}
+
+func ExampleMetrics() {
+	metrics := &expvarMetrics{
+		pollDuration:   expvar.NewFloat("feed_poll_duration_seconds"),
+		eventsReceived: expvar.NewInt("feed_events_received_total"),
+		fetchErrors:    expvar.NewInt("feed_fetch_errors_total"),
+	}
+
+	client := pkg.NewClient(pkg.ClientOptions{
+		Metrics: metrics,
+	})
+
+	_ = client
+}
@@ -0,0 +1,52 @@
+package pkg
+
+import "time"
+
+// IdlePollingOptions configures a longer poll delay for feeds which have been idle for a while, as a simpler
+// alternative to AdaptivePollingOptions with just two delays. Only used for simple polling, as long-polling servers
+// respond as soon as there are new events anyway.
+type IdlePollingOptions struct {
+	// EmptyPolls is the number of consecutive polls without events after which the feed is considered idle. Idle
+	// polling is disabled when zero.
+	EmptyPolls int
+
+	// Delay is the delay between polls of an idle feed. The regular poll delay is used again after the first poll
+	// with events.
+	Delay time.Duration
+}
+
+// idleDelay keeps track of the consecutive empty polls of a single subscription with idle polling.
+type idleDelay struct {
+	opts       IdlePollingOptions
+	emptyPolls int
+}
+
+func newIdleDelay(opts IdlePollingOptions) *idleDelay {
+	return &idleDelay{opts: opts}
+}
+
+// next records a poll that returned n events. Returns whether the feed became idle or active again with this poll.
+func (d *idleDelay) next(n int) (changed bool) {
+	wasIdle := d.idle()
+	if n == 0 {
+		d.emptyPolls++
+	} else {
+		d.emptyPolls = 0
+	}
+
+	return d.idle() != wasIdle
+}
+
+// idle tells whether the feed is idle.
+func (d *idleDelay) idle() bool {
+	return d.emptyPolls >= d.opts.EmptyPolls
+}
+
+// delay returns the delay until the next poll.
+func (d *idleDelay) delay(pollDelay time.Duration) time.Duration {
+	if d.idle() {
+		return d.opts.Delay
+	}
+
+	return pollDelay
+}
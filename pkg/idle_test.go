@@ -0,0 +1,69 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdleDelay(t *testing.T) {
+	d := newIdleDelay(IdlePollingOptions{EmptyPolls: 2, Delay: time.Minute})
+
+	assert.False(t, d.next(0))
+	assert.Equal(t, time.Second, d.delay(time.Second))
+	assert.True(t, d.next(0), "the feed becomes idle after two empty polls")
+	assert.Equal(t, time.Minute, d.delay(time.Second))
+	assert.False(t, d.next(0))
+	assert.Equal(t, time.Minute, d.delay(time.Second))
+	assert.True(t, d.next(3), "the feed is active again after the first poll with events")
+	assert.Equal(t, time.Second, d.delay(time.Second))
+	assert.False(t, d.next(0), "the empty polls are counted again")
+}
+
+func TestClient_Subscribe_IdlePolling(t *testing.T) {
+	var requests atomic.Int32
+
+	// 1. Set up a test server with an idle feed
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Query().Get("lastEventId") == "1" {
+			fmt.Fprintln(w, `[{"id":"2"}]`)
+			return
+		}
+		fmt.Fprintln(w, `[]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{
+		PollDelay:   10 * time.Millisecond,
+		IdlePolling: IdlePollingOptions{EmptyPolls: 3, Delay: time.Hour},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	events := make(chan Event)
+	go func() {
+		_ = client.Subscribe(ts.URL, "", events, ctx)
+	}()
+
+	// 2. Expect the polling to slow down after three empty polls
+	assert.Eventually(t, func() bool {
+		return requests.Load() == 3
+	}, time.Second, 5*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(3), requests.Load())
+
+	// 3. Expect the regular poll delay again once the feed returns events
+	assert.NoError(t, client.Seek(ts.URL, "1"))
+	assert.Equal(t, "2", (<-events).ID)
+	assert.Eventually(t, func() bool {
+		return requests.Load() >= 6
+	}, time.Second, 5*time.Millisecond)
+}
@@ -0,0 +1,32 @@
+package pkg
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+)
+
+// LatestEventIdHeader is the response header by which a feed server reports the ID of the latest event of the feed,
+// e.g. `X-Latest-Event-Id: 42`. It lets SubscribeFromNow start with a single request instead of reading the feed to
+// its end. The FeedHandler sets it if its EventStore implements LatestEventStore.
+const LatestEventIdHeader = "X-Latest-Event-Id"
+
+// latestEventIdKey is the context key of the latest event ID recorded by the requests of tailEventId.
+type latestEventIdKey struct{}
+
+// withLatestEventId returns a context in which the requests record the latest event ID reported by the server.
+func withLatestEventId(ctx context.Context, latest *atomic.Pointer[string]) context.Context {
+	return context.WithValue(ctx, latestEventIdKey{}, latest)
+}
+
+// recordLatestEventId records the latest event ID reported by a response into the request carried by ctx.
+func recordLatestEventId(resp *http.Response, ctx context.Context) {
+	latest, ok := ctx.Value(latestEventIdKey{}).(*atomic.Pointer[string])
+	if !ok {
+		return
+	}
+
+	if id := resp.Header.Get(LatestEventIdHeader); id != "" {
+		latest.Store(&id)
+	}
+}
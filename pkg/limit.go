@@ -0,0 +1,41 @@
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrResponseTooLarge is returned when a response body exceeds ClientOptions.MaxResponseBytes.
+var ErrResponseTooLarge = errors.New("response too large")
+
+// limitReader reads from r until limit bytes have been read, failing with ErrResponseTooLarge if r has more data.
+type limitReader struct {
+	r         io.Reader
+	limit     int64
+	remaining int64
+}
+
+func newLimitReader(r io.Reader, limit int64) *limitReader {
+	return &limitReader{r: r, limit: limit, remaining: limit}
+}
+
+func (l *limitReader) Read(p []byte) (int, error) {
+	if l.remaining <= 0 {
+		// Probe for more data beyond the limit
+		var b [1]byte
+		n, err := l.r.Read(b[:])
+		if n > 0 {
+			return 0, fmt.Errorf("%w: exceeds %d bytes", ErrResponseTooLarge, l.limit)
+		}
+		return 0, err
+	}
+
+	if int64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+
+	return n, err
+}
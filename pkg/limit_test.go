@@ -0,0 +1,91 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimitReader(t *testing.T) {
+	b, err := io.ReadAll(newLimitReader(strings.NewReader("12345"), 5))
+	assert.NoError(t, err)
+	assert.Equal(t, "12345", string(b))
+
+	b, err = io.ReadAll(newLimitReader(strings.NewReader("123456"), 5))
+	assert.True(t, errors.Is(err, ErrResponseTooLarge))
+	assert.Equal(t, "12345", string(b))
+}
+
+func TestClient_fetchEvents_MaxResponseBytes(t *testing.T) {
+	// 1. Set up a test server returning a large response
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `[{"id":"1"},{"id":"2","subject":"%s"}]`, strings.Repeat("x", 1000))
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Expect the response to fail beyond the limit, after the events up to it
+	client := NewClient(ClientOptions{MaxResponseBytes: 100})
+
+	var ids []string
+	_, err := client.streamEvents(ts.URL, "", func(e Event) error {
+		ids = append(ids, e.ID)
+		return nil
+	}, ctx)
+	assert.True(t, errors.Is(err, ErrResponseTooLarge))
+	assert.Equal(t, []string{"1"}, ids)
+
+	// 3. Expect responses within the limit to succeed
+	client = NewClient(ClientOptions{MaxResponseBytes: 2000})
+
+	events, err := client.fetchEvents(ts.URL, "", ctx)
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+}
+
+func TestClient_SubscribeBatches_MaxResponseBytes(t *testing.T) {
+	// 1. Set up a test server whose first response exceeds the limit
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("lastEventId") {
+		case "":
+			fmt.Fprintf(w, `[{"id":"1"},{"id":"2","subject":"%s"}]`, strings.Repeat("x", 1000))
+		case "1":
+			fmt.Fprintln(w, `[{"id":"2"}]`)
+		default:
+			fmt.Fprintln(w, `[]`)
+		}
+	}))
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{
+		PollDelay:        10 * time.Millisecond,
+		MaxResponseBytes: 100,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	batches := make(chan []Event)
+	go func() {
+		_ = client.SubscribeBatches(ts.URL, "", batches, ctx)
+	}()
+
+	// 2. Expect the events up to the limit to be delivered, so that the next poll continues after them
+	batch := <-batches
+	assert.Len(t, batch, 1)
+	assert.Equal(t, "1", batch[0].ID)
+
+	batch = <-batches
+	assert.Len(t, batch, 1)
+	assert.Equal(t, "2", batch[0].ID)
+}
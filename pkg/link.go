@@ -0,0 +1,57 @@
+package pkg
+
+import "strings"
+
+// findLink returns the target of the first link with the relation type rel in the values of Link headers as
+// defined in RFC 8288, e.g. `<https://example.org/feed?page=2>; rel="next"`. Returns an empty string if there is none.
+func findLink(headers []string, rel string) string {
+	for _, header := range headers {
+		for _, link := range splitLinks(header) {
+			parts := strings.Split(link, ";")
+
+			target := strings.TrimSpace(parts[0])
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+
+			for _, param := range parts[1:] {
+				key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+				if !ok || !strings.EqualFold(strings.TrimSpace(key), "rel") {
+					continue
+				}
+
+				// The relation type may be a space separated list
+				for _, r := range strings.Fields(strings.Trim(strings.TrimSpace(value), `"`)) {
+					if strings.EqualFold(r, rel) {
+						return target[1 : len(target)-1]
+					}
+				}
+			}
+		}
+	}
+
+	return ""
+}
+
+// splitLinks splits the value of a Link header into its links, ignoring commas inside of the link targets.
+func splitLinks(header string) []string {
+	var links []string
+
+	inTarget := false
+	start := 0
+	for i, r := range header {
+		switch r {
+		case '<':
+			inTarget = true
+		case '>':
+			inTarget = false
+		case ',':
+			if !inTarget {
+				links = append(links, header[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return append(links, header[start:])
+}
@@ -0,0 +1,54 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindLink(t *testing.T) {
+	headers := []string{`<https://example.org/feed?a=1,2>; rel="prev", <https://example.org/feed?page=2>; rel="next last"`}
+
+	assert.Equal(t, "https://example.org/feed?page=2", findLink(headers, "next"))
+	assert.Equal(t, "https://example.org/feed?a=1,2", findLink(headers, "prev"))
+	assert.Equal(t, "", findLink(headers, "first"))
+	assert.Equal(t, "", findLink(nil, "next"))
+}
+
+func TestClient_fetchEvents_FollowNextLinks(t *testing.T) {
+	// 1. Set up a test server with three pages
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "":
+			w.Header().Set("Link", `</feed?page=2>; rel="next"`)
+			fmt.Fprintln(w, `[{"id":"1"}]`)
+		case "2":
+			w.Header().Set("Link", `</feed?page=3>; rel="next"`)
+			fmt.Fprintln(w, `[{"id":"2"}]`)
+		default:
+			fmt.Fprintln(w, `[{"id":"3"}]`)
+		}
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Test that all pages are fetched
+	client := NewClient(ClientOptions{FollowNextLinks: true})
+	events, err := client.fetchEvents(ts.URL, "", ctx)
+	assert.NoError(t, err)
+	assert.Len(t, events, 3)
+	assert.Equal(t, "3", events[2].ID)
+
+	// 3. Test that links are ignored by default
+	client = NewClient(ClientOptions{})
+	events, err = client.fetchEvents(ts.URL, "", ctx)
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+}
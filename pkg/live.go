@@ -0,0 +1,43 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+)
+
+// LinkRelationLive is the relation type of the Link header by which the last page of an archive links to the live
+// feed continuing it, e.g. `<https://example.org/feed/live>; rel="live"`, see ClientOptions.FollowLiveLinks.
+const LinkRelationLive = "live"
+
+// liveLinkKey is the context key of the live link recorded by the requests of a poll.
+type liveLinkKey struct{}
+
+// withLiveLink returns a context in which the requests of a poll record the live link of the last archive page.
+func withLiveLink(ctx context.Context, live *atomic.Pointer[url.URL]) context.Context {
+	return context.WithValue(ctx, liveLinkKey{}, live)
+}
+
+// recordLiveLink records the live link of a response into the poll carried by ctx. Only the last page of an archive
+// links to the live feed, so pages with a next link are ignored.
+func recordLiveLink(resp *http.Response, next *url.URL, ctx context.Context) error {
+	live, ok := ctx.Value(liveLinkKey{}).(*atomic.Pointer[url.URL])
+	if !ok || next != nil {
+		return nil
+	}
+
+	link := findLink(resp.Header.Values("Link"), LinkRelationLive)
+	if link == "" {
+		return nil
+	}
+
+	u, err := resp.Request.URL.Parse(link)
+	if err != nil {
+		return fmt.Errorf("invalid live link %q: %w", link, err)
+	}
+	live.Store(u)
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_Subscribe_FollowLiveLinks(t *testing.T) {
+	// 1. Set up a test server with a paginated archive whose last page links to the live feed
+	mux := http.NewServeMux()
+	mux.HandleFunc("/archive", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "":
+			assert.Equal(t, "", r.URL.Query().Get("lastEventId"))
+			w.Header().Set("Link", `</archive?page=2>; rel="next"`)
+			fmt.Fprintln(w, `[{"id":"1"},{"id":"2"}]`)
+		default:
+			w.Header().Set("Link", `</live>; rel="live"`)
+			fmt.Fprintln(w, `[{"id":"3"}]`)
+		}
+	})
+	mux.HandleFunc("/live", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("lastEventId") {
+		case "3":
+			fmt.Fprintln(w, `[{"id":"4"}]`)
+		case "4":
+			fmt.Fprintln(w, `[]`)
+		default:
+			assert.Fail(t, "unexpected cursor", r.URL.Query().Get("lastEventId"))
+			fmt.Fprintln(w, `[]`)
+		}
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	cursor := NewFileCursor(filepath.Join(t.TempDir(), "cursor"))
+	client := NewClient(ClientOptions{
+		PollDelay:       10 * time.Millisecond,
+		FollowNextLinks: true,
+		FollowLiveLinks: true,
+		Cursor:          cursor,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	events := make(chan Event)
+	go func() {
+		_ = client.Subscribe(ts.URL+"/archive", "", events, ctx)
+	}()
+
+	// 2. Expect the archive to be consumed first and the live feed to continue from its last event
+	for _, id := range []string{"1", "2", "3", "4"} {
+		e := <-events
+		assert.Equal(t, id, e.ID)
+		assert.Equal(t, ts.URL+"/archive", e.Endpoint)
+	}
+
+	assert.Eventually(t, func() bool {
+		stored, _ := cursor.Load()
+		return stored == "4"
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestClient_Subscribe_FollowLiveLinksDisabled(t *testing.T) {
+	// 1. Set up a test server whose feed links to a live feed
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/archive", r.URL.Path)
+		w.Header().Set("Link", `</live>; rel="live"`)
+		fmt.Fprintln(w, `[]`)
+	}))
+	defer ts.Close()
+
+	polls := make(chan struct{}, 10)
+	client := NewClient(ClientOptions{
+		PollDelay: 10 * time.Millisecond,
+		OnPoll: func(PollResult) {
+			polls <- struct{}{}
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	go func() {
+		_ = client.Subscribe(ts.URL+"/archive", "", make(chan Event), ctx)
+	}()
+
+	// 2. Expect the live link to be ignored by default
+	<-polls
+	<-polls
+}
@@ -0,0 +1,52 @@
+package pkg
+
+import (
+	"context"
+	"sync"
+)
+
+// FeedConfig describes one of the feeds subscribed to with SubscribeMany.
+type FeedConfig struct {
+	// Endpoint is the HTTP endpoint of the feed.
+	Endpoint string
+
+	// LastEventId is the last event ID received from the feed. Leave empty to start from the beginning.
+	LastEventId string
+
+	// Cursor persists the position in this feed. The Cursor of the ClientOptions is not used by SubscribeMany,
+	// as the feeds can't share one.
+	Cursor Cursor
+}
+
+// SubscribeMany subscribes to several feeds at once and sends the events of all of them to one channel.
+// Event.Endpoint tells which feed an event was received from. The order of events is preserved per feed only.
+// SubscribeMany blocks until all subscriptions have ended. The first fatal error of any subscription cancels the
+// others and is returned.
+func (c *Client) SubscribeMany(feeds []FeedConfig, events chan Event, ctx context.Context) error {
+	return c.sendBuffered(events, func(send func(Event) error) error {
+		return c.subscribeMany(feeds, send, ctx)
+	}, ctx)
+}
+
+// subscribeMany runs the subscriptions of SubscribeMany, passing the events of all feeds to send.
+func (c *Client) subscribeMany(feeds []FeedConfig, send func(Event) error, ctx context.Context) error {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	var wg sync.WaitGroup
+	for _, feed := range feeds {
+		wg.Add(1)
+		go func(feed FeedConfig) {
+			defer wg.Done()
+
+			err := c.subscribe(feed.Endpoint, feed.LastEventId, feed.Cursor, nil, handlers{event: send}, ctx)
+			if err != nil {
+				cancel(err)
+			}
+		}(feed)
+	}
+	wg.Wait()
+
+	// All subscriptions end with the cancellation error of the shared context, return the error which caused it
+	return context.Cause(ctx)
+}
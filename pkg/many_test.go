@@ -0,0 +1,67 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_SubscribeMany(t *testing.T) {
+	// 1. Setup two test servers
+	newServer := func(id string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("lastEventId") == id {
+				fmt.Fprintln(w, `[]`)
+				return
+			}
+
+			fmt.Fprintf(w, `[{"id":"%s"}]`, id)
+		}))
+	}
+	ts1 := newServer("a")
+	defer ts1.Close()
+	ts2 := newServer("b")
+	defer ts2.Close()
+
+	events := make(chan Event)
+	client := NewClient(ClientOptions{PollDelay: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	go func() {
+		_ = client.SubscribeMany([]FeedConfig{{Endpoint: ts1.URL}, {Endpoint: ts2.URL}}, events, ctx)
+	}()
+
+	// 2. Expect the events of both feeds, tagged with their endpoint
+	received := map[string]string{}
+	for i := 0; i < 2; i++ {
+		e := <-events
+		received[e.ID] = e.Endpoint
+	}
+
+	assert.Equal(t, map[string]string{"a": ts1.URL, "b": ts2.URL}, received)
+}
+
+func TestClient_SubscribeMany_fatalError(t *testing.T) {
+	// 1. Setup a test server
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `[]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{PollDelay: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Expect the invalid endpoint to end all subscriptions
+	err := client.SubscribeMany([]FeedConfig{{Endpoint: ts.URL}, {Endpoint: "://invalid"}}, make(chan Event), ctx)
+	assert.ErrorContains(t, err, "missing protocol scheme")
+	assert.NoError(t, ctx.Err())
+}
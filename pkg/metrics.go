@@ -0,0 +1,52 @@
+package pkg
+
+import "time"
+
+// Metrics receives measurements of the polling of subscriptions. Implement it to export the measurements to a
+// monitoring system like Prometheus. Implementations must be safe for concurrent use, as they are shared by all
+// subscriptions of a Client.
+type Metrics interface {
+	// ObservePollDuration is called after every poll with the duration of the request, including the delivery
+	// of the received events.
+	ObservePollDuration(d time.Duration)
+
+	// IncEventsReceived is called after every successful poll with the number of delivered events.
+	IncEventsReceived(n int)
+
+	// IncFetchError is called for every failed poll.
+	IncFetchError()
+}
+
+// PollResult describes a completed poll, see ClientOptions.OnPoll.
+type PollResult struct {
+	// Endpoint is the endpoint of the feed.
+	Endpoint string
+
+	// RequestID is the ID sent in the X-Request-Id header of the requests of the poll.
+	RequestID string
+
+	// Events is the number of events received by the poll.
+	Events int
+
+	// Duration is the duration of the request, including the delivery of the received events.
+	Duration time.Duration
+
+	// LongPollTimeout tells whether the poll was a long-poll which ended without new events.
+	LongPollTimeout bool
+
+	// Err is the error of a failed poll. Errors returned by the handlers are not reported.
+	Err error
+}
+
+// CircuitBreakerMetrics can additionally be implemented by Metrics to record the state of circuit breakers, see
+// ClientOptions.CircuitBreaker. ObserveCircuitOpen is called every time the circuit of a subscription opens or closes.
+type CircuitBreakerMetrics interface {
+	ObserveCircuitOpen(open bool)
+}
+
+// noopMetrics is the default Metrics that discards all measurements.
+type noopMetrics struct{}
+
+func (noopMetrics) ObservePollDuration(time.Duration) {}
+func (noopMetrics) IncEventsReceived(int)             {}
+func (noopMetrics) IncFetchError()                    {}
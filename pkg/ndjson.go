@@ -0,0 +1,47 @@
+package pkg
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// MediaTypeNDJSON is the media type of a feed response containing newline-delimited JSON events.
+const MediaTypeNDJSON = "application/x-ndjson"
+
+// decodeNDJSON decodes a response with one event per line and passes the events to handle as they are read, so that
+// the subscription advances line by line. Empty lines are ignored.
+func decodeNDJSON(body io.Reader, opts decodeOptions, handle func(Event) error) (int, error) {
+	reader := bufio.NewReader(body)
+
+	n := 0
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return n, err
+		}
+		eof := errors.Is(err, io.EOF)
+
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			event, err := opts.decode(line)
+			if err != nil {
+				err = fmt.Errorf("could not decode NDJSON line: %w", err)
+				if opts.skip == nil {
+					return n, err
+				}
+				opts.skip(fmt.Errorf("skipping malformed event: %w", err))
+			} else {
+				if err := handle(event); err != nil {
+					return n, err
+				}
+				n++
+			}
+		}
+
+		if eof {
+			return n, nil
+		}
+	}
+}
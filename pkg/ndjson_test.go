@@ -0,0 +1,78 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeNDJSON(t *testing.T) {
+	body := "{\"id\":\"1\",\"type\":\"a\"}\n" +
+		"\n" +
+		"{\"id\":2}\r\n" +
+		"{\"id\":\"3\"}"
+
+	var events []Event
+	var skipped []error
+	opts := decodeOptions{decoder: DefaultDecoder, skip: func(err error) { skipped = append(skipped, err) }}
+	n, err := decodeNDJSON(strings.NewReader(body), opts, func(e Event) error {
+		events = append(events, e)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, "1", events[0].ID)
+	assert.Equal(t, "a", events[0].Type)
+	assert.Equal(t, "3", events[1].ID)
+	assert.Len(t, skipped, 1)
+
+	// Without skipping, a malformed line fails the response
+	_, err = decodeNDJSON(strings.NewReader(body), decodeOptions{decoder: DefaultDecoder}, func(e Event) error {
+		return nil
+	})
+	assert.Error(t, err)
+}
+
+func TestClient_Subscribe_NDJSON(t *testing.T) {
+	release := make(chan struct{})
+
+	// 1. Setup a test server that streams the events of a response line by line
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", MediaTypeNDJSON)
+		if r.URL.Query().Get("lastEventId") != "" {
+			return
+		}
+
+		fmt.Fprintln(w, `{"id":"1"}`)
+		w.(http.Flusher).Flush()
+		<-release
+		fmt.Fprintln(w, `{"id":"2"}`)
+	}))
+	defer ts.Close()
+
+	events := make(chan Event)
+	client := NewClient(ClientOptions{PollDelay: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	go func() {
+		_ = client.Subscribe(ts.URL, "", events, ctx)
+	}()
+
+	// 2. Expect the first event to be delivered before the response is complete
+	assert.Equal(t, "1", (<-events).ID)
+	assert.Eventually(t, func() bool {
+		lastEventId, _ := client.LastEventId(ts.URL)
+		return lastEventId == "1"
+	}, time.Second, 10*time.Millisecond)
+
+	close(release)
+	assert.Equal(t, "2", (<-events).ID)
+}
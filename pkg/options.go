@@ -0,0 +1,118 @@
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrInvalidOptions is returned by NewClientWithError for ClientOptions which can't work.
+var ErrInvalidOptions = errors.New("invalid client options")
+
+// Validate checks the options for values which are out of range or contradict each other. Returns all problems
+// found, each wrapping ErrInvalidOptions.
+func (opts ClientOptions) Validate() error {
+	var errs []error
+	invalid := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%w: "+format, append([]any{ErrInvalidOptions}, args...)...))
+	}
+
+	if opts.PollDelay < 0 {
+		invalid("PollDelay must not be negative, got %s", opts.PollDelay)
+	}
+	if opts.Timeout < 0 {
+		invalid("Timeout must not be negative, got %s", opts.Timeout)
+	}
+	if opts.RequestTimeout < 0 {
+		invalid("RequestTimeout must not be negative, got %s", opts.RequestTimeout)
+	}
+	if opts.LongPollMargin < 0 {
+		invalid("LongPollMargin must not be negative, got %s", opts.LongPollMargin)
+	}
+	if opts.Timeout > 0 && opts.RequestTimeout > 0 && opts.RequestTimeout <= opts.Timeout {
+		invalid("RequestTimeout (%s) must be longer than the long-polling Timeout (%s)", opts.RequestTimeout, opts.Timeout)
+	}
+
+	if opts.RetryBackoff.InitialDelay < 0 || opts.RetryBackoff.MaxDelay < 0 {
+		invalid("RetryBackoff delays must not be negative")
+	}
+	if opts.RetryBackoff.Jitter < 0 || opts.RetryBackoff.Jitter > 1 {
+		invalid("RetryBackoff.Jitter must be between 0 and 1, got %v", opts.RetryBackoff.Jitter)
+	}
+
+	if opts.AdaptivePolling.MinDelay < 0 || opts.AdaptivePolling.MaxDelay < 0 {
+		invalid("AdaptivePolling delays must not be negative")
+	}
+	if opts.AdaptivePolling.MaxDelay > 0 && opts.AdaptivePolling.MinDelay > opts.AdaptivePolling.MaxDelay {
+		invalid("AdaptivePolling.MinDelay (%s) must not be longer than MaxDelay (%s)",
+			opts.AdaptivePolling.MinDelay, opts.AdaptivePolling.MaxDelay)
+	}
+
+	if opts.IdlePolling.EmptyPolls < 0 || opts.IdlePolling.Delay < 0 {
+		invalid("IdlePolling options must not be negative")
+	}
+	if opts.IdlePolling.EmptyPolls > 0 && opts.AdaptivePolling.MaxDelay > 0 {
+		invalid("IdlePolling can't be combined with AdaptivePolling")
+	}
+
+	if opts.CircuitBreaker.FailureThreshold < 0 || opts.CircuitBreaker.Cooldown < 0 {
+		invalid("CircuitBreaker options must not be negative")
+	}
+	if opts.Buffer.Size < 0 {
+		invalid("Buffer.Size must not be negative, got %d", opts.Buffer.Size)
+	}
+	if opts.Buffer.Overflow < OverflowBlock || opts.Buffer.Overflow > OverflowDropNewest {
+		invalid("unknown Buffer.Overflow %d", opts.Buffer.Overflow)
+	}
+
+	if opts.DeduplicationWindow < 0 {
+		invalid("DeduplicationWindow must not be negative, got %d", opts.DeduplicationWindow)
+	}
+	if opts.BatchSize < 0 {
+		invalid("BatchSize must not be negative, got %d", opts.BatchSize)
+	}
+	if opts.BootstrapConcurrency < 0 {
+		invalid("BootstrapConcurrency must not be negative, got %d", opts.BootstrapConcurrency)
+	}
+	if opts.MaxResponseBytes < 0 {
+		invalid("MaxResponseBytes must not be negative, got %d", opts.MaxResponseBytes)
+	}
+	if opts.TailSize < 0 {
+		invalid("TailSize must not be negative, got %d", opts.TailSize)
+	}
+	if opts.MaxDuration < 0 {
+		invalid("MaxDuration must not be negative, got %v", opts.MaxDuration)
+	}
+	if opts.MaxEvents < 0 {
+		invalid("MaxEvents must not be negative, got %d", opts.MaxEvents)
+	}
+	if opts.StartupJitter < 0 {
+		invalid("StartupJitter must not be negative, got %s", opts.StartupJitter)
+	}
+	method := strings.ToUpper(opts.Method)
+	if method != "" && method != http.MethodGet && method != http.MethodHead {
+		invalid("Method must be GET or HEAD, got %q", opts.Method)
+	}
+	if method == http.MethodHead && opts.RequestBody != nil {
+		invalid("Method HEAD can't be used with a RequestBody")
+	}
+	if opts.Verifier != nil && opts.Transport != TransportPolling {
+		invalid("Verifier can only be used with TransportPolling")
+	}
+	if opts.Transport < TransportPolling || opts.Transport > TransportStream {
+		invalid("unknown Transport %d", opts.Transport)
+	}
+
+	return errors.Join(errs...)
+}
+
+// NewClientWithError creates a new Client like NewClient, but validates the options first and returns an error
+// wrapping ErrInvalidOptions if they are invalid.
+func NewClientWithError(opts ClientOptions) (*Client, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	return NewClient(opts), nil
+}
@@ -0,0 +1,69 @@
+package pkg
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClientWithError(t *testing.T) {
+	client, err := NewClientWithError(ClientOptions{Timeout: 10 * time.Second})
+	assert.NoError(t, err)
+	assert.NotNil(t, client)
+
+	client, err = NewClientWithError(ClientOptions{Method: "head"})
+	assert.NoError(t, err)
+	assert.NotNil(t, client)
+
+	tests := map[string]ClientOptions{
+		"negative poll delay":      {PollDelay: -1},
+		"negative timeout":         {Timeout: -1},
+		"short request timeout":    {Timeout: 10 * time.Second, RequestTimeout: 5 * time.Second},
+		"negative backoff":         {RetryBackoff: BackoffOptions{InitialDelay: -1}},
+		"jitter out of range":      {RetryBackoff: BackoffOptions{Jitter: 2}},
+		"adaptive min greater max": {AdaptivePolling: AdaptivePollingOptions{MinDelay: time.Second, MaxDelay: time.Millisecond}},
+		"negative idle delay":      {IdlePolling: IdlePollingOptions{EmptyPolls: 3, Delay: -1}},
+		"idle and adaptive":        {IdlePolling: IdlePollingOptions{EmptyPolls: 3, Delay: time.Minute}, AdaptivePolling: AdaptivePollingOptions{MaxDelay: time.Minute}},
+		"negative dedup window":    {Deduplicate: true, DeduplicationWindow: -1},
+		"negative batch size":      {BatchSize: -1},
+		"negative buffer size":     {Buffer: BufferOptions{Size: -1}},
+		"unknown overflow policy":  {Buffer: BufferOptions{Size: 1, Overflow: OverflowPolicy(42)}},
+		"unknown transport":        {Transport: Transport(42)},
+		"verifier with sse":        {Transport: TransportSSE, Verifier: NewHMACVerifier(nil, "")},
+		"unsafe method":            {Method: http.MethodDelete},
+		"head with request body":   {Method: http.MethodHead, RequestBody: map[string]string{}},
+	}
+	for name, opts := range tests {
+		t.Run(name, func(t *testing.T) {
+			client, err := NewClientWithError(opts)
+			assert.Nil(t, client)
+			assert.True(t, errors.Is(err, ErrInvalidOptions))
+		})
+	}
+}
+
+func TestClientOptions_Validate_AllErrors(t *testing.T) {
+	err := ClientOptions{PollDelay: -1, Timeout: -1}.Validate()
+	assert.ErrorContains(t, err, "PollDelay")
+	assert.ErrorContains(t, err, "Timeout")
+}
+
+func TestNewClient_NegativeDurations(t *testing.T) {
+	client := NewClient(ClientOptions{
+		PollDelay:      -1,
+		Timeout:        -1,
+		RequestTimeout: -1,
+		LongPollMargin: -1,
+		MaxEvents:      -1,
+	})
+	assert.Equal(t, DefaultPollDelay, client.pollDelay)
+	assert.Equal(t, time.Duration(0), client.timeout)
+	assert.Equal(t, DefaultRequestTimeout, client.requestTimeout)
+	assert.Equal(t, 0, client.maxEvents)
+
+	assert.Equal(t, DefaultAdaptiveMinDelay, newAdaptiveDelay(AdaptivePollingOptions{MinDelay: -1, MaxDelay: time.Second}, 0).next(1))
+	assert.Equal(t, time.Second, newBackoff(BackoffOptions{InitialDelay: -1, MaxDelay: -1}, time.Second).next())
+}
@@ -0,0 +1,91 @@
+package pkg
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/url"
+)
+
+// prefetchedPage is a page of a paginated response which is requested before the events of the previous pages have
+// been delivered, see ClientOptions.BootstrapConcurrency. The other fields are set once done is closed.
+type prefetchedPage struct {
+	url  *url.URL
+	done chan struct{}
+
+	// read tells whether the body has been received, which isn't the case for failed requests or 304 responses
+	read        bool
+	body        []byte
+	contentType string
+	next        *url.URL
+	err         error
+}
+
+// prefetchPages fetches the pages of a paginated response like streamPages, but with up to BootstrapConcurrency
+// pages at once. Every page is requested as soon as the headers of the previous page have been received, and is
+// buffered until the events of the previous pages have been passed to handle, so that the order is preserved.
+func (c *Client) prefetchPages(u *url.URL, handle func(Event) error, ctx context.Context) (int, error) {
+	// Abort the requests of the pages beyond the end of the feed
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pages := make(chan *prefetchedPage, c.prefetch-1)
+	p := &prefetchedPage{url: u, done: make(chan struct{})}
+	go c.fetchAhead(p, pages, ctx)
+
+	total := 0
+	for {
+		<-p.done
+		n := 0
+		if p.read {
+			var err error
+			n, err = c.decodePage(page{body: bytes.NewReader(p.body), contentType: p.contentType}, handle, ctx)
+			total += n
+			if err != nil {
+				return total, err
+			}
+		}
+		if p.err != nil {
+			return total, p.err
+		}
+
+		// An empty page marks the end of the feed, the pages prefetched beyond it are discarded
+		if p.next == nil || n == 0 || p.next.String() == p.url.String() {
+			return total, nil
+		}
+
+		c.logger.Debug("following next link", "url", p.next.String())
+		select {
+		case p = <-pages:
+		case <-ctx.Done():
+			return total, ctx.Err()
+		}
+	}
+}
+
+// fetchAhead requests the page p and reads its body into memory. The next page is queued in pages and requested as
+// soon as the next link is known and there is room in the queue.
+func (c *Client) fetchAhead(p *prefetchedPage, pages chan<- *prefetchedPage, ctx context.Context) {
+	defer close(p.done)
+
+	_, _, p.err = c.requestPage(p.url, func(resp page) (int, error) {
+		if resp.next != nil && resp.next.String() != p.url.String() {
+			next := &prefetchedPage{url: resp.next, done: make(chan struct{})}
+			go func() {
+				select {
+				case pages <- next:
+					c.fetchAhead(next, pages, ctx)
+				case <-ctx.Done():
+				}
+			}()
+		}
+
+		b, err := io.ReadAll(resp.body)
+		if err != nil {
+			return 0, err
+		}
+		p.read, p.body, p.contentType, p.next = true, b, resp.contentType, resp.next
+
+		return 0, nil
+	}, ctx)
+}
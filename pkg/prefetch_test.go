@@ -0,0 +1,99 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_fetchEvents_BootstrapConcurrency(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+
+	// 1. Set up a test server with slow pages, where the third page is empty but still links to a fourth page
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			seen := maxInFlight.Load()
+			if current <= seen || maxInFlight.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+
+		pageNo, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		w.Header().Set("Content-Type", "application/json")
+		if pageNo < 6 {
+			w.Header().Set("Link", fmt.Sprintf(`</feed?page=%d>; rel="next"`, pageNo+1))
+		}
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+
+		time.Sleep(50 * time.Millisecond)
+		if pageNo == 3 {
+			fmt.Fprintln(w, `[]`)
+			return
+		}
+		fmt.Fprintf(w, `[{"id":"%d-a"},{"id":"%d-b"}]`, pageNo, pageNo)
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	client := NewClient(ClientOptions{
+		FollowNextLinks:      true,
+		BootstrapConcurrency: 3,
+	})
+
+	// 2. Expect the pages to be fetched concurrently and the events to be delivered in order
+	events, lastEventId, err := client.Fetch(ts.URL, "", ctx)
+	assert.NoError(t, err)
+	if assert.Len(t, events, 6) {
+		for i, id := range []string{"0-a", "0-b", "1-a", "1-b", "2-a", "2-b"} {
+			assert.Equal(t, id, events[i].ID)
+		}
+	}
+	assert.Equal(t, "2-b", lastEventId)
+	assert.Greater(t, maxInFlight.Load(), int32(1))
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(3))
+}
+
+func TestClient_fetchEvents_BootstrapConcurrencyError(t *testing.T) {
+	// 1. Set up a test server whose second page fails
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "":
+			w.Header().Set("Link", `</feed?page=2>; rel="next"`)
+			fmt.Fprintln(w, `[{"id":"1"}]`)
+		case "2":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			fmt.Fprintln(w, `[{"id":"3"}]`)
+		}
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Expect the events before the failed page to be delivered and the error to be returned
+	client := NewClient(ClientOptions{
+		FollowNextLinks:      true,
+		BootstrapConcurrency: 4,
+	})
+
+	var ids []string
+	_, err := client.streamEvents(ts.URL, "", func(e Event) error {
+		ids = append(ids, e.ID)
+		return nil
+	}, ctx)
+	assert.Error(t, err)
+	assert.Equal(t, []string{"1"}, ids)
+}
@@ -0,0 +1,11 @@
+package pkg
+
+import "context"
+
+// RateLimiter limits the rate of the requests a Client sends to the feed servers. It is satisfied by
+// *rate.Limiter of golang.org/x/time/rate. Implementations must be safe for concurrent use, as they are shared by
+// all subscriptions of a Client.
+type RateLimiter interface {
+	// Wait blocks until the next request may be sent. Returns an error if ctx is done before.
+	Wait(ctx context.Context) error
+}
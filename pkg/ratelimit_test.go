@@ -0,0 +1,79 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// intervalLimiter allows one request per interval.
+type intervalLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func (l *intervalLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	wait := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	select {
+	case <-time.After(wait):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func TestClient_Subscribe_RateLimiter(t *testing.T) {
+	var requests int32
+
+	// 1. Set up a failing test server, which would be retried after every poll delay
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{
+		PollDelay:   time.Millisecond,
+		RateLimiter: &intervalLimiter{interval: 50 * time.Millisecond},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 230*time.Millisecond)
+	defer cancel()
+
+	// 2. Expect the requests to be limited and the subscription to end with the context
+	err := client.Subscribe(ts.URL, "", make(chan Event), ctx)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	n := atomic.LoadInt32(&requests)
+	assert.GreaterOrEqual(t, n, int32(3))
+	assert.LessOrEqual(t, n, int32(5))
+}
+
+func TestClient_fetchEvents_RateLimiterCancelled(t *testing.T) {
+	client := NewClient(ClientOptions{
+		RateLimiter: &intervalLimiter{interval: time.Hour, next: time.Now().Add(time.Hour)},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// Expect the wait for the limiter to end with the context
+	_, err := client.fetchEvents("http://localhost", "", ctx)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
@@ -0,0 +1,38 @@
+package pkg
+
+import (
+	"context"
+	"io"
+)
+
+// SubscribeReader delivers the events of a recorded feed read from r, e.g. a file opened with os.Open, without any
+// HTTP requests. This allows replaying captured feeds offline and in deterministic tests. r contains a JSON array of
+// events, or newline-delimited JSON with ForceNDJSON. The events are sent to events as they are decoded, honoring the
+// Decoder, SkipInvalidEvents, KeepRawEvents and ValidateEvents options. SubscribeReader returns once all events have
+// been delivered, or with the error of ctx if it is cancelled before.
+func (c *Client) SubscribeReader(r io.Reader, events chan Event, ctx context.Context) error {
+	send := sendTo(events, ctx)
+	handle := func(event Event) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if c.validateEvents {
+			if err := event.Validate(); err != nil {
+				c.handleError(err, ctx)
+				return nil
+			}
+		}
+
+		return send(event)
+	}
+
+	var err error
+	if c.forceNDJSON {
+		_, err = decodeNDJSON(r, c.decodeOptions(ctx), handle)
+	} else {
+		_, err = decodeEvents(r, "application/json", c.decodeOptions(ctx), handle)
+	}
+
+	return err
+}
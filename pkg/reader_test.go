@@ -0,0 +1,47 @@
+package pkg
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_SubscribeReader(t *testing.T) {
+	// 1. Record a feed to a file
+	path := filepath.Join(t.TempDir(), "feed.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`[{"id":"1","type":"a"},{"id":"2","type":"b"}]`), 0o644))
+
+	f, err := os.Open(path)
+	assert.NoError(t, err)
+	defer f.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Expect all events to be delivered
+	events := make(chan Event, 2)
+	err = NewClient(ClientOptions{}).SubscribeReader(f, events, ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", (<-events).ID)
+	assert.Equal(t, "b", (<-events).Type)
+}
+
+func TestClient_SubscribeReader_cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Expect the replay to stop once the context is cancelled
+	events := make(chan Event)
+	errs := make(chan error)
+	go func() {
+		errs <- NewClient(ClientOptions{ForceNDJSON: true}).SubscribeReader(strings.NewReader("{\"id\":\"1\"}\n{\"id\":\"2\"}\n"), events, ctx)
+	}()
+
+	assert.Equal(t, "1", (<-events).ID)
+	cancel()
+	assert.ErrorIs(t, <-errs, context.Canceled)
+}
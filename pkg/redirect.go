@@ -0,0 +1,127 @@
+package pkg
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// maxRedirects is the number of redirects followed per request, like the default of http.Client.
+const maxRedirects = 10
+
+// RedirectOptions controls how redirect responses of feed servers are handled. By default, redirects are followed for
+// every request like http.Client does, and the Authorization header is dropped on redirects to other hosts.
+type RedirectOptions struct {
+	// Disable stops following redirects. Redirect responses fail the poll with a FeedHTTPError instead.
+	Disable bool
+
+	// Pin remembers where the requests of a feed have been redirected to, and sends the following polls to the
+	// redirect target directly. Use it for feeds which have moved permanently, e.g. from HTTP to HTTPS. The original
+	// endpoint is still reported in Event.Endpoint and the Stats.
+	Pin bool
+
+	// ForwardAuthorization keeps the Authorization header on redirects to other hosts. Only enable it if all hosts
+	// the feed redirects to are trusted: a compromised or misconfigured feed server could otherwise redirect the
+	// requests to a host that collects the credentials. Redirects from HTTPS to plain HTTP would also send the
+	// credentials unencrypted.
+	ForwardAuthorization bool
+}
+
+func (o RedirectOptions) isZero() bool {
+	return o == RedirectOptions{}
+}
+
+// checkRedirect implements http.Client.CheckRedirect.
+func (o RedirectOptions) checkRedirect(req *http.Request, via []*http.Request) error {
+	if o.Disable {
+		return http.ErrUseLastResponse
+	}
+	if len(via) >= maxRedirects {
+		return errors.New("stopped after 10 redirects")
+	}
+
+	// The HTTP client has already dropped the header if the host has changed
+	if o.ForwardAuthorization && req.Header.Get("Authorization") == "" {
+		if authorization := via[0].Header.Get("Authorization"); authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+	}
+
+	return nil
+}
+
+// withRedirectOptions returns a copy of client that handles redirects according to opts.
+func withRedirectOptions(client *http.Client, opts RedirectOptions) *http.Client {
+	if !opts.Disable && !opts.ForwardAuthorization {
+		return client
+	}
+
+	c := *client
+	c.CheckRedirect = opts.checkRedirect
+	return &c
+}
+
+// redirectCache remembers the redirect targets of feeds for RedirectOptions.Pin. It is shared by all subscriptions of
+// a Client.
+type redirectCache struct {
+	mu     sync.Mutex
+	feeds  map[string]*url.URL
+	params []string
+}
+
+// newRedirectCache creates a redirectCache. The query parameters in params are set for each poll and are not pinned.
+func newRedirectCache(params ...string) *redirectCache {
+	return &redirectCache{feeds: map[string]*url.URL{}, params: params}
+}
+
+// pin remembers that the request to u has been redirected to target.
+func (c *redirectCache) pin(u, target *url.URL) {
+	if feedKey(u) == feedKey(target) {
+		return
+	}
+
+	pinned := *target
+	query := pinned.Query()
+	for _, param := range c.params {
+		query.Del(param)
+	}
+	pinned.RawQuery = query.Encode()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.feeds[feedKey(u)] = &pinned
+}
+
+// resolve returns the URL to poll instead of u, following redirects pinned one after another.
+func (c *redirectCache) resolve(u *url.URL) *url.URL {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	resolved := u
+	for i := 0; i < maxRedirects; i++ {
+		pinned, ok := c.feeds[feedKey(resolved)]
+		if !ok {
+			break
+		}
+		resolved = pinned
+	}
+	if resolved == u {
+		return u
+	}
+
+	// Keep the parameters of the poll, and the credentials of the endpoint for redirects to the same host
+	target := *resolved
+	query, pollQuery := target.Query(), u.Query()
+	for _, param := range c.params {
+		if values, ok := pollQuery[param]; ok {
+			query[param] = values
+		}
+	}
+	target.RawQuery = query.Encode()
+	if target.User == nil && target.Host == u.Host {
+		target.User = u.User
+	}
+	return &target
+}
@@ -0,0 +1,111 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_Subscribe_RedirectPin(t *testing.T) {
+	var redirects atomic.Int32
+
+	// 1. Set up a test server whose feed has moved to a new path
+	mux := http.NewServeMux()
+	mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+		redirects.Add(1)
+		http.Redirect(w, r, "/new?"+r.URL.RawQuery, http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/new", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "tenant-1", r.URL.Query().Get("tenant"))
+		switch r.URL.Query().Get("lastEventId") {
+		case "":
+			fmt.Fprintln(w, `[{"id":"1"}]`)
+		case "1":
+			fmt.Fprintln(w, `[{"id":"2"}]`)
+		default:
+			fmt.Fprintln(w, `[]`)
+		}
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	events := make(chan Event)
+	client := NewClient(ClientOptions{
+		PollDelay: 10 * time.Millisecond,
+		Redirects: RedirectOptions{Pin: true},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	endpoint := ts.URL + "/old?tenant=tenant-1"
+	go func() {
+		_ = client.Subscribe(endpoint, "", events, ctx)
+	}()
+
+	// 2. Expect only the first poll to be redirected, while the events still report the original endpoint
+	ev := <-events
+	assert.Equal(t, "1", ev.ID)
+	assert.Equal(t, endpoint, ev.Endpoint)
+	assert.Equal(t, "2", (<-events).ID)
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(1), redirects.Load())
+}
+
+func TestClient_fetchEvents_RedirectsDisabled(t *testing.T) {
+	// 1. Set up a test server redirecting every request
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/elsewhere", http.StatusFound)
+	}))
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{Redirects: RedirectOptions{Disable: true}})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Expect the redirect to fail the request
+	_, err := client.fetchEvents(ts.URL, "", ctx)
+	var httpErr *FeedHTTPError
+	assert.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusFound, httpErr.StatusCode)
+}
+
+func TestClient_fetchEvents_RedirectForwardAuthorization(t *testing.T) {
+	// 1. Set up a target server and a server redirecting to it under another host name
+	var authorization atomic.Value
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization.Store(r.Header.Get("Authorization"))
+		fmt.Fprintln(w, `[]`)
+	}))
+	defer target.Close()
+
+	targetURL := strings.Replace(target.URL, "127.0.0.1", "localhost", 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, targetURL, http.StatusTemporaryRedirect)
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Expect the header to be dropped by default
+	client := NewClient(ClientOptions{AuthToken: "secret"})
+	_, err := client.fetchEvents(ts.URL, "", ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "", authorization.Load())
+
+	// 3. Expect the header to be forwarded when enabled
+	client = NewClient(ClientOptions{AuthToken: "secret", Redirects: RedirectOptions{ForwardAuthorization: true}})
+	_, err = client.fetchEvents(ts.URL, "", ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer secret", authorization.Load())
+}
@@ -0,0 +1,43 @@
+package pkg
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+)
+
+// RequestIDHeader is the header carrying the ID of every request, see ClientOptions.RequestIDGenerator.
+const RequestIDHeader = "X-Request-Id"
+
+// requestIDKey is the context key of the ID of the current poll.
+type requestIDKey struct{}
+
+// withRequestID returns a context carrying the request ID of a poll, which is sent with all of its requests.
+func withRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// requestIDFromContext returns the request ID of the poll carried by ctx.
+func requestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok
+}
+
+// requestID returns the request ID of the poll carried by ctx, or a new one for requests outside of a poll.
+func (c *Client) requestID(ctx context.Context) string {
+	if id, ok := requestIDFromContext(ctx); ok {
+		return id
+	}
+
+	return c.newRequestID()
+}
+
+// randomRequestID is the default request ID generator, returning a random UUID (version 4).
+func randomRequestID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,47 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRandomRequestID(t *testing.T) {
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	// 1. Expect random version 4 UUIDs
+	a, b := randomRequestID(), randomRequestID()
+	assert.Regexp(t, uuid, a)
+	assert.Regexp(t, uuid, b)
+	assert.NotEqual(t, a, b)
+}
+
+func TestClient_Fetch_RequestID(t *testing.T) {
+	// 1. Set up a test server recording the request IDs
+	requestIDs := make(chan string, 10)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestIDs <- r.Header.Get(RequestIDHeader)
+		fmt.Fprintln(w, `[]`)
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Expect a new ID for every request outside of a subscription
+	client := NewClient(ClientOptions{})
+	_, _, err := client.Fetch(ts.URL, "", ctx)
+	assert.NoError(t, err)
+	_, _, err = client.Fetch(ts.URL, "", ctx)
+	assert.NoError(t, err)
+
+	first, second := <-requestIDs, <-requestIDs
+	assert.NotEmpty(t, first)
+	assert.NotEqual(t, first, second)
+}
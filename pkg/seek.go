@@ -0,0 +1,103 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+)
+
+// ErrNoSubscription is returned by Seek if no subscription to the endpoint is running.
+var ErrNoSubscription = errors.New("no running subscription")
+
+// seekRegistry keeps the seek channels of the running subscriptions of a Client, keyed by the endpoint.
+type seekRegistry struct {
+	mu   sync.Mutex
+	subs map[string]map[chan string]struct{}
+}
+
+func newSeekRegistry() *seekRegistry {
+	return &seekRegistry{subs: map[string]map[chan string]struct{}{}}
+}
+
+// register adds a subscription to endpoint. The returned channel receives the event IDs to seek to, only the latest
+// of them is kept until the subscription picks it up. Call the returned function when the subscription ends.
+func (r *seekRegistry) register(endpoint string) (<-chan string, func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	seeks := make(chan string, 1)
+	if r.subs[endpoint] == nil {
+		r.subs[endpoint] = map[chan string]struct{}{}
+	}
+	r.subs[endpoint][seeks] = struct{}{}
+
+	return seeks, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		delete(r.subs[endpoint], seeks)
+		if len(r.subs[endpoint]) == 0 {
+			delete(r.subs, endpoint)
+		}
+	}
+}
+
+// seek passes lastEventId to all subscriptions to endpoint, replacing IDs they haven't picked up yet.
+func (r *seekRegistry) seek(endpoint, lastEventId string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for seeks := range r.subs[endpoint] {
+		select {
+		case <-seeks:
+		default:
+		}
+		seeks <- lastEventId
+	}
+
+	return len(r.subs[endpoint]) > 0
+}
+
+// Seek moves the running subscriptions to the feed at endpoint to lastEventId, e.g. to replay the events after an
+// earlier ID once a bug in a consumer has been fixed, without restarting the subscriptions. An empty lastEventId
+// replays the whole feed. The subscriptions pick up the new position between two polls, so the events of a poll in
+// progress are still delivered, and poll from the new position right away, unless their circuit breaker is open, in
+// which case the next probe polls from there. The position is also saved to the Cursor. Returns ErrNoSubscription if no subscription to endpoint is running.
+func (c *Client) Seek(endpoint, lastEventId string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+
+	if !c.seeks.seek(u.String(), lastEventId) {
+		return fmt.Errorf("%w to %s", ErrNoSubscription, u.Redacted())
+	}
+
+	return nil
+}
+
+// seekTo moves the subscription to lastEventId. It is called from the subscription goroutine between two polls, so
+// it can't interfere with the advancing of the subscription.
+func (c *Client) seekTo(u *url.URL, sub *subscription, lastEventId string, ctx context.Context) {
+	c.logger.Info("seeking subscription", "endpoint", u.Redacted(), "from", sub.lastEventId, "to", lastEventId)
+
+	// The replayed events are neither duplicates nor caught up yet, and may only be in the archive
+	sub.lastEventId = lastEventId
+	sub.caughtUp = false
+	sub.live = nil
+	if sub.delivered != nil {
+		sub.delivered = newIDCache(c.dedupWindow)
+	}
+	if sub.sequences != nil {
+		sub.sequences = sequences{}
+	}
+
+	c.stats.seeked(u.String(), lastEventId)
+	if sub.cursor != nil {
+		if err := sub.cursor.Save(lastEventId); err != nil {
+			c.handleError(fmt.Errorf("could not save cursor: %w", err), ctx)
+		}
+	}
+}
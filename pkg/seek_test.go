@@ -0,0 +1,146 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_Seek(t *testing.T) {
+	// 1. Set up a test server with three events
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("lastEventId") {
+		case "":
+			fmt.Fprintln(w, `[{"id":"1"},{"id":"2"},{"id":"3"}]`)
+		case "1":
+			fmt.Fprintln(w, `[{"id":"2"},{"id":"3"}]`)
+		default:
+			fmt.Fprintln(w, `[]`)
+		}
+	}))
+	defer ts.Close()
+
+	cursor := NewFileCursor(filepath.Join(t.TempDir(), "cursor"))
+	client := NewClient(ClientOptions{
+		PollDelay:   10 * time.Millisecond,
+		Deduplicate: true,
+		Cursor:      cursor,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Expect an error without a running subscription
+	err := client.Seek(ts.URL, "1")
+	assert.True(t, errors.Is(err, ErrNoSubscription))
+
+	events := make(chan Event)
+	go func() {
+		_ = client.Subscribe(ts.URL, "", events, ctx)
+	}()
+
+	for _, id := range []string{"1", "2", "3"} {
+		assert.Equal(t, id, (<-events).ID)
+	}
+
+	// 3. Expect the events after the new position to be delivered again, although they are duplicates
+	assert.NoError(t, client.Seek(ts.URL, "1"))
+	assert.Equal(t, "2", (<-events).ID)
+	assert.Equal(t, "3", (<-events).ID)
+
+	assert.Eventually(t, func() bool {
+		stored, _ := cursor.Load()
+		lastEventId, _ := client.LastEventId(ts.URL)
+		return stored == "3" && lastEventId == "3"
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestClient_Seek_Concurrent(t *testing.T) {
+	// 1. Set up a test server which keeps returning the event after the cursor
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var id int
+		fmt.Sscan(r.URL.Query().Get("lastEventId"), &id)
+		fmt.Fprintf(w, `[{"id":"%d"}]`, id+1)
+	}))
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{PollDelay: time.Millisecond})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	events := make(chan Event)
+	go func() {
+		_ = client.Subscribe(ts.URL, "", events, ctx)
+	}()
+	<-events
+
+	// 2. Expect seeking while events are delivered to end up at the last position
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, client.Seek(ts.URL, "100"))
+		<-events
+	}
+	assert.NoError(t, client.Seek(ts.URL, "500"))
+
+	for {
+		select {
+		case e := <-events:
+			if e.ID == "501" {
+				return
+			}
+		case <-ctx.Done():
+			assert.Fail(t, "subscription didn't seek to the last position")
+			return
+		}
+	}
+}
+
+func TestClient_Seek_CircuitOpen(t *testing.T) {
+	polls := make(chan string, 10)
+
+	// 1. Set up a failing test server
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls <- r.URL.Query().Get("lastEventId")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{
+		PollDelay: 10 * time.Millisecond,
+		CircuitBreaker: CircuitBreakerOptions{
+			FailureThreshold: 1,
+			Cooldown:         200 * time.Millisecond,
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	go func() {
+		_ = client.Subscribe(ts.URL, "", make(chan Event), ctx)
+	}()
+
+	assert.Equal(t, "", <-polls)
+	assert.Eventually(t, func() bool {
+		return client.Stats().Feeds[ts.URL].CircuitOpen
+	}, time.Second, 5*time.Millisecond)
+
+	// 2. Expect a seek not to poll before the cooldown has passed, and the probe to poll from the new position
+	start := time.Now()
+	assert.NoError(t, client.Seek(ts.URL, "1"))
+
+	select {
+	case lastEventId := <-polls:
+		assert.Equal(t, "1", lastEventId)
+		assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+	case <-ctx.Done():
+		t.Fatal("no probe after the cooldown")
+	}
+}
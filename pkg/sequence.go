@@ -0,0 +1,55 @@
+package pkg
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+)
+
+// SequenceExtension is the name of the CloudEvents extension attribute which carries the position of an event in the
+// order of its source, see Event.Sequence and ClientOptions.OrderBySequence.
+const SequenceExtension = "sequence"
+
+// Sequence returns the sequence extension attribute of the event, a number which increases with every event of the
+// same source. Unlike Time, it doesn't depend on the clock of the producer, so it is the better choice for ordering
+// events. Both numbers and decimal strings are accepted, as the CloudEvents specification encodes the sequence as a
+// string. Returns false if the event has no sequence or it isn't a non-negative integer.
+func (e Event) Sequence() (uint64, bool) {
+	switch value := e.Extensions[SequenceExtension].(type) {
+	case string:
+		sequence, err := strconv.ParseUint(value, 10, 64)
+		return sequence, err == nil
+	case json.Number:
+		sequence, err := strconv.ParseUint(value.String(), 10, 64)
+		return sequence, err == nil
+	case float64:
+		if value < 0 || value != math.Trunc(value) || value >= math.MaxUint64 {
+			return 0, false
+		}
+		return uint64(value), true
+	default:
+		return 0, false
+	}
+}
+
+// sequences keeps the sequence of the last delivered event of each source of a subscription.
+type sequences map[string]uint64
+
+// stale tells whether the event has a sequence which isn't greater than the last delivered one of its source, i.e.
+// the event or a later one of its source has been delivered already.
+func (s sequences) stale(e Event) bool {
+	sequence, ok := e.Sequence()
+	if !ok {
+		return false
+	}
+
+	last, ok := s[e.Source]
+	return ok && sequence <= last
+}
+
+// add records the sequence of a delivered event.
+func (s sequences) add(e Event) {
+	if sequence, ok := e.Sequence(); ok {
+		s[e.Source] = sequence
+	}
+}
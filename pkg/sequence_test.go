@@ -0,0 +1,113 @@
+package pkg
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvent_Sequence(t *testing.T) {
+	tests := map[string]struct {
+		value    interface{}
+		expected uint64
+		ok       bool
+	}{
+		"string":           {value: "42", expected: 42, ok: true},
+		"number":           {value: float64(42), expected: 42, ok: true},
+		"json number":      {value: json.Number("42"), expected: 42, ok: true},
+		"missing":          {value: nil},
+		"negative":         {value: float64(-1)},
+		"fraction":         {value: 1.5},
+		"not a number":     {value: "abc"},
+		"unsupported type": {value: true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			e := Event{}
+			if test.value != nil {
+				e.Extensions = map[string]interface{}{SequenceExtension: test.value}
+			}
+
+			sequence, ok := e.Sequence()
+			assert.Equal(t, test.ok, ok)
+			assert.Equal(t, test.expected, sequence)
+		})
+	}
+}
+
+func TestClient_Subscribe_OrderBySequence(t *testing.T) {
+	// 1. Set up a test server which sends a stale event of source "a" after a newer one
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("lastEventId") == "" {
+			fmt.Fprintln(w, `[
+				{"id":"1","source":"a","sequence":"2"},
+				{"id":"2","source":"b","sequence":"1"},
+				{"id":"3","source":"a","sequence":"1"},
+				{"id":"4"},
+				{"id":"5","source":"a","sequence":"3"}
+			]`)
+			return
+		}
+		fmt.Fprintln(w, `[]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{
+		PollDelay:       10 * time.Millisecond,
+		OrderBySequence: true,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	events := make(chan Event)
+	go func() {
+		_ = client.Subscribe(ts.URL, "", events, ctx)
+	}()
+
+	// 2. Expect the stale event to be skipped, while the sequences of other sources and events without one don't matter
+	for _, id := range []string{"1", "2", "4", "5"} {
+		assert.Equal(t, id, (<-events).ID)
+	}
+}
+
+func TestClient_Subscribe_FromTimeClockSkew(t *testing.T) {
+	fromTime := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	// 1. Set up a test server ignoring the fromTime parameter, with a producer whose clock lags behind
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("lastEventId") == "" {
+			fmt.Fprintln(w, `[
+				{"id":"1","time":"2021-02-01T00:00:00Z"},
+				{"id":"2","time":"2021-03-01T12:00:00Z"},
+				{"id":"3","time":"2021-03-01T11:59:00Z"}
+			]`)
+			return
+		}
+		fmt.Fprintln(w, `[]`)
+	}))
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{
+		PollDelay: 10 * time.Millisecond,
+		FromTime:  fromTime,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	events := make(chan Event)
+	go func() {
+		_ = client.Subscribe(ts.URL, "", events, ctx)
+	}()
+
+	// 2. Expect the events after the first one at FromTime to be delivered in order, despite their earlier time
+	assert.Equal(t, "2", (<-events).ID)
+	assert.Equal(t, "3", (<-events).ID)
+}
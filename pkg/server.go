@@ -0,0 +1,177 @@
+package pkg
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const DefaultBatchSize = 1000
+const DefaultMaxLongPollTimeout = 30 * time.Second
+const DefaultStorePollInterval = 100 * time.Millisecond
+
+// ErrEventNotFound is returned by an EventStore when the requested lastEventId is unknown.
+var ErrEventNotFound = errors.New("event not found")
+
+// EventStore is the source of the events served by a FeedHandler.
+type EventStore interface {
+	// After returns up to limit events following the event with the given ID, in feed order.
+	// An empty lastEventId returns events from the beginning of the feed. Returns ErrEventNotFound if the event is unknown.
+	After(lastEventId string, limit int) ([]Event, error)
+}
+
+// EventNotifier can be implemented by an EventStore to wake up long-polling requests as soon as events are added.
+// Stores that don't implement it are polled periodically while a long-polling request is waiting.
+type EventNotifier interface {
+	// Notify returns a channel that is closed when new events are added to the store.
+	Notify() <-chan struct{}
+}
+
+// LatestEventStore can be implemented by an EventStore to report the ID of its latest event in the
+// LatestEventIdHeader of every response, so that clients subscribing with SubscribeFromNow don't have to read the
+// whole feed.
+type LatestEventStore interface {
+	// LatestEventId returns the ID of the latest event, or an empty string if the store has no events.
+	LatestEventId() (string, error)
+}
+
+// FeedHandler serves an HTTP feed from an EventStore. It implements simple polling and long-polling.
+type FeedHandler struct {
+	store             EventStore
+	batchSize         int
+	maxTimeout        time.Duration
+	storePollInterval time.Duration
+}
+
+type FeedHandlerOptions struct {
+	// BatchSize is the maximum number of events returned per response. Defaults to 1000.
+	BatchSize int
+
+	// MaxTimeout caps the timeout requested by clients for long-polling. Defaults to 30 seconds.
+	MaxTimeout time.Duration
+
+	// StorePollInterval is the interval in which the store is queried for new events while a long-polling request is
+	// waiting. Only used if the store does not implement EventNotifier. Defaults to 100 milliseconds.
+	StorePollInterval time.Duration
+}
+
+// NewFeedHandler creates a new FeedHandler serving the events of store.
+func NewFeedHandler(store EventStore, opts FeedHandlerOptions) *FeedHandler {
+	batchSize := opts.BatchSize
+	if batchSize == 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	maxTimeout := opts.MaxTimeout
+	if maxTimeout == 0 {
+		maxTimeout = DefaultMaxLongPollTimeout
+	}
+
+	storePollInterval := opts.StorePollInterval
+	if storePollInterval == 0 {
+		storePollInterval = DefaultStorePollInterval
+	}
+
+	return &FeedHandler{
+		store:             store,
+		batchSize:         batchSize,
+		maxTimeout:        maxTimeout,
+		storePollInterval: storePollInterval,
+	}
+}
+
+// ServeHTTP responds with the events following the lastEventId query parameter as a JSON array.
+// If the timeout query parameter is set and there are no new events, the request is held open until events
+// are available or the timeout in milliseconds has passed.
+func (h *FeedHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	lastEventId := query.Get("lastEventId")
+
+	var timeout time.Duration
+	if t := query.Get("timeout"); t != "" {
+		ms, err := strconv.ParseInt(t, 10, 64)
+		if err != nil || ms < 0 {
+			http.Error(w, fmt.Sprintf("invalid timeout: %s", t), http.StatusBadRequest)
+			return
+		}
+
+		timeout = time.Duration(ms) * time.Millisecond
+		if timeout > h.maxTimeout {
+			timeout = h.maxTimeout
+		}
+	}
+
+	events, err := h.waitForEvents(r, lastEventId, timeout)
+	if errors.Is(err, ErrEventNotFound) {
+		http.Error(w, fmt.Sprintf("unknown lastEventId: %s", lastEventId), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if events == nil {
+		events = []Event{}
+	}
+
+	if store, ok := h.store.(LatestEventStore); ok {
+		latest, err := store.LatestEventId()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if latest != "" {
+			w.Header().Set(LatestEventIdHeader, latest)
+		}
+	}
+
+	w.Header().Set("Content-Type", MediaTypeCloudEventsBatch)
+	_ = json.NewEncoder(w).Encode(events)
+}
+
+// waitForEvents queries the store for events after lastEventId. When there are none, it waits up to timeout for new
+// events to arrive.
+func (h *FeedHandler) waitForEvents(r *http.Request, lastEventId string, timeout time.Duration) ([]Event, error) {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+
+	for {
+		// Subscribe to notifications before querying, so that events added in between aren't missed
+		var notify <-chan struct{}
+		if notifier, ok := h.store.(EventNotifier); ok {
+			notify = notifier.Notify()
+		}
+
+		events, err := h.store.After(lastEventId, h.batchSize)
+		if err != nil {
+			return nil, err
+		}
+		if len(events) > 0 || timeout == 0 {
+			return events, nil
+		}
+
+		var storePoll <-chan time.Time
+		if notify == nil {
+			storePoll = time.After(h.storePollInterval)
+		}
+
+		select {
+		case <-r.Context().Done():
+			return nil, nil
+		case <-deadline.C:
+			return nil, nil
+		case <-notify:
+		case <-storePoll:
+		}
+	}
+}
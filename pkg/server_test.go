@@ -0,0 +1,126 @@
+package pkg
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// sliceStore is a minimal EventStore for testing the FeedHandler.
+type sliceStore struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (s *sliceStore) append(events ...Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, events...)
+}
+
+func (s *sliceStore) After(lastEventId string, limit int) ([]Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	start := 0
+	for i, e := range s.events {
+		if e.ID == lastEventId {
+			start = i + 1
+		}
+	}
+
+	end := start + limit
+	if end > len(s.events) {
+		end = len(s.events)
+	}
+
+	return append([]Event(nil), s.events[start:end]...), nil
+}
+
+func TestFeedHandler_ServeHTTP(t *testing.T) {
+	store := &sliceStore{}
+	store.append(Event{ID: "1"}, Event{ID: "2"}, Event{ID: "3"})
+
+	ts := httptest.NewServer(NewFeedHandler(store, FeedHandlerOptions{BatchSize: 2}))
+	defer ts.Close()
+
+	// 1. Expect the first batch from the beginning of the feed
+	resp, err := http.Get(ts.URL + "?lastEventId=")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	var events []Event
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&events))
+	assert.Equal(t, MediaTypeCloudEventsBatch, resp.Header.Get("Content-Type"))
+	assert.Len(t, events, 2)
+	assert.Equal(t, "1", events[0].ID)
+	assert.Equal(t, "2", events[1].ID)
+
+	// 2. Expect an empty array at the end of the feed
+	resp, err = http.Get(ts.URL + "?lastEventId=3")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	events = nil
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&events))
+	assert.NotNil(t, events)
+	assert.Len(t, events, 0)
+}
+
+func TestFeedHandler_ServeHTTP_invalidTimeout(t *testing.T) {
+	ts := httptest.NewServer(NewFeedHandler(&sliceStore{}, FeedHandlerOptions{}))
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "?timeout=abc")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestFeedHandler_ServeHTTP_LongPolling(t *testing.T) {
+	store := &sliceStore{}
+	ts := httptest.NewServer(NewFeedHandler(store, FeedHandlerOptions{StorePollInterval: 10 * time.Millisecond}))
+	defer ts.Close()
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		store.append(Event{ID: "1"})
+	}()
+
+	// 1. Expect the client to receive the event appended while waiting
+	client := NewClient(ClientOptions{
+		PollDelay: 10 * time.Millisecond,
+		Timeout:   1 * time.Second,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	e, err := client.fetchEvents(ts.URL, "", ctx)
+	assert.NoError(t, err)
+	assert.Len(t, e, 1)
+	assert.Less(t, time.Since(start), 1*time.Second)
+}
+
+func TestFeedHandler_ServeHTTP_LongPollingTimeout(t *testing.T) {
+	ts := httptest.NewServer(NewFeedHandler(&sliceStore{}, FeedHandlerOptions{}))
+	defer ts.Close()
+
+	start := time.Now()
+	resp, err := http.Get(ts.URL + "?lastEventId=&timeout=50")
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	var events []Event
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&events))
+	assert.Len(t, events, 0)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+}
@@ -0,0 +1,121 @@
+package pkg
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// MediaTypeEventStream is the media type of a server-sent events stream.
+const MediaTypeEventStream = "text/event-stream"
+
+// decodeSSE decodes a server-sent events stream and passes the events to handle as they arrive. The data of every
+// message is either a single event or a JSON array of events. Events without an ID get the ID of the message, so
+// that the subscription resumes from it after reconnecting. Returns when the stream ends.
+func decodeSSE(body io.Reader, opts decodeOptions, handle func(Event) error) (int, error) {
+	reader := bufio.NewReader(body)
+
+	n := 0
+	var id string
+	var data strings.Builder
+
+	dispatch := func() error {
+		defer data.Reset()
+		if data.Len() == 0 {
+			return nil
+		}
+
+		events, err := decodeMessage([]byte(data.String()), opts)
+		if err != nil {
+			err = fmt.Errorf("could not decode event stream message: %w", err)
+			if opts.skip == nil {
+				return err
+			}
+			opts.skip(fmt.Errorf("skipping malformed event: %w", err))
+			return nil
+		}
+
+		for _, e := range events {
+			if e.ID == "" {
+				e.ID = id
+			}
+			if err := handle(e); err != nil {
+				return err
+			}
+			n++
+		}
+
+		return nil
+	}
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return n, err
+		}
+		eof := errors.Is(err, io.EOF)
+		line = strings.TrimRight(line, "\r\n")
+
+		// An empty line dispatches the message, an incomplete message at the end of the stream is discarded
+		if line == "" {
+			if eof {
+				return n, nil
+			}
+			if err := dispatch(); err != nil {
+				return n, err
+			}
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+
+		switch field {
+		case "data":
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(value)
+		case "id":
+			if !strings.ContainsRune(value, 0) {
+				id = value
+			}
+		}
+
+		if eof {
+			return n, nil
+		}
+	}
+}
+
+// decodeMessage decodes the data of a pushed message, which is a single event or a JSON array of events.
+func decodeMessage(data []byte, opts decodeOptions) ([]Event, error) {
+	data = bytes.TrimSpace(data)
+	if !bytes.HasPrefix(data, []byte("[")) {
+		e, err := opts.decode(data)
+		if err != nil {
+			return nil, err
+		}
+		return []Event{e}, nil
+	}
+
+	var raw []json.RawMessage
+	if err := opts.decoder.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	events := make([]Event, len(raw))
+	for i := range raw {
+		e, err := opts.decode(raw[i])
+		if err != nil {
+			return nil, err
+		}
+		events[i] = e
+	}
+
+	return events, nil
+}
@@ -0,0 +1,79 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeSSE(t *testing.T) {
+	stream := ": comment\n" +
+		"id: 1\n" +
+		"data: {\"type\":\"a\"}\n" +
+		"\n" +
+		"event: batch\n" +
+		"data: [{\"id\":\"2\"},\n" +
+		"data: {\"id\":\"3\"}]\n" +
+		"\n" +
+		"id: 4\n" +
+		"data: {\"id\":\"incomplete\"}\n"
+
+	var events []Event
+	n, err := decodeSSE(strings.NewReader(stream), decodeOptions{decoder: DefaultDecoder}, func(e Event) error {
+		events = append(events, e)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, "1", events[0].ID)
+	assert.Equal(t, "a", events[0].Type)
+	assert.Equal(t, "2", events[1].ID)
+	assert.Equal(t, "3", events[2].ID)
+}
+
+func TestClient_Subscribe_SSE(t *testing.T) {
+	// 1. Setup a test server that streams events and closes the stream after each batch
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, MediaTypeEventStream, r.Header.Get("Accept"))
+		assert.Equal(t, "", r.URL.Query().Get("timeout"))
+		lastEventIdHeader := r.Header.Get("Last-Event-ID")
+
+		w.Header().Set("Content-Type", MediaTypeEventStream)
+		if lastEventIdHeader == "" {
+			fmt.Fprint(w, "id: 1\ndata: {\"id\":\"1\"}\n\n")
+			w.(http.Flusher).Flush()
+			fmt.Fprint(w, "id: 2\ndata: {\"id\":\"2\"}\n\n")
+			return
+		}
+
+		// 2. The reconnect resumes from the last received message
+		assert.Equal(t, "2", lastEventIdHeader)
+		fmt.Fprint(w, "id: 3\ndata: {\"id\":\"3\"}\n\n")
+	}))
+	defer ts.Close()
+
+	events := make(chan Event)
+	client := NewClient(ClientOptions{
+		PollDelay: 10 * time.Millisecond,
+		Timeout:   100 * time.Millisecond,
+		Transport: TransportSSE,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	go func() {
+		_ = client.Subscribe(ts.URL, "", events, ctx)
+	}()
+
+	// 3. Expect the events of both connections
+	assert.Equal(t, "1", (<-events).ID)
+	assert.Equal(t, "2", (<-events).ID)
+	assert.Equal(t, "3", (<-events).ID)
+}
@@ -0,0 +1,152 @@
+package pkg
+
+import (
+	"sync"
+	"time"
+)
+
+// Stats is a snapshot of the state of the subscriptions of a Client, returned by Client.Stats.
+type Stats struct {
+	// EventsDelivered is the total number of events delivered by all subscriptions.
+	EventsDelivered int64
+
+	// EventsDropped is the total number of events dropped from the buffers of all subscriptions, see
+	// ClientOptions.Buffer.
+	EventsDropped int64
+
+	// LastPoll is the start time of the most recent poll of any feed. Zero if no feed was polled yet.
+	LastPoll time.Time
+
+	// LastSuccessfulPoll is the end time of the most recent successful poll of any feed.
+	LastSuccessfulPoll time.Time
+
+	// Feeds contains the state of each subscribed feed, keyed by the endpoint.
+	Feeds map[string]FeedStats
+}
+
+// FeedStats is a snapshot of the state of the subscription to a single feed.
+type FeedStats struct {
+	// EventsDelivered is the number of events delivered from the feed.
+	EventsDelivered int64
+
+	// EventsDropped is the number of events from the feed which were dropped from the buffer.
+	EventsDropped int64
+
+	// LastPoll is the start time of the most recent poll of the feed.
+	LastPoll time.Time
+
+	// LastSuccessfulPoll is the end time of the most recent successful poll of the feed.
+	LastSuccessfulPoll time.Time
+
+	// LastEventId is the ID of the last event delivered from the feed.
+	LastEventId string
+
+	// ConsecutiveErrors is the number of polls which failed since the last successful poll.
+	ConsecutiveErrors int
+
+	// CircuitOpen tells whether the circuit breaker of the subscription is open, i.e. polling is paused.
+	CircuitOpen bool
+}
+
+// statsRecorder records the Stats of a Client. It is shared by all subscriptions of the Client.
+type statsRecorder struct {
+	mu    sync.Mutex
+	total Stats
+}
+
+func newStatsRecorder() *statsRecorder {
+	return &statsRecorder{total: Stats{Feeds: map[string]FeedStats{}}}
+}
+
+// update applies f to the stats of the feed at endpoint.
+func (r *statsRecorder) update(endpoint string, f func(total *Stats, feed *FeedStats)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	feed := r.total.Feeds[endpoint]
+	f(&r.total, &feed)
+	r.total.Feeds[endpoint] = feed
+}
+
+func (r *statsRecorder) polling(endpoint string, now time.Time) {
+	r.update(endpoint, func(total *Stats, feed *FeedStats) {
+		total.LastPoll = now
+		feed.LastPoll = now
+	})
+}
+
+func (r *statsRecorder) delivered(endpoint, id string) {
+	r.update(endpoint, func(total *Stats, feed *FeedStats) {
+		total.EventsDelivered++
+		feed.EventsDelivered++
+		feed.LastEventId = id
+	})
+}
+
+func (r *statsRecorder) dropped(endpoint string) {
+	r.update(endpoint, func(total *Stats, feed *FeedStats) {
+		total.EventsDropped++
+		feed.EventsDropped++
+	})
+}
+
+func (r *statsRecorder) seeked(endpoint, id string) {
+	r.update(endpoint, func(total *Stats, feed *FeedStats) {
+		feed.LastEventId = id
+	})
+}
+
+func (r *statsRecorder) succeeded(endpoint string, now time.Time) {
+	r.update(endpoint, func(total *Stats, feed *FeedStats) {
+		total.LastSuccessfulPoll = now
+		feed.LastSuccessfulPoll = now
+		feed.ConsecutiveErrors = 0
+	})
+}
+
+func (r *statsRecorder) failed(endpoint string) {
+	r.update(endpoint, func(total *Stats, feed *FeedStats) {
+		feed.ConsecutiveErrors++
+	})
+}
+
+func (r *statsRecorder) circuit(endpoint string, open bool) {
+	r.update(endpoint, func(total *Stats, feed *FeedStats) {
+		feed.CircuitOpen = open
+	})
+}
+
+// snapshot returns a copy of the stats which is safe to use while the subscriptions continue.
+func (r *statsRecorder) snapshot() Stats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats := r.total
+	stats.Feeds = make(map[string]FeedStats, len(r.total.Feeds))
+	for endpoint, feed := range r.total.Feeds {
+		stats.Feeds[endpoint] = feed
+	}
+
+	return stats
+}
+
+// Stats returns a snapshot of the state of the subscriptions of the client, e.g. for health checks. It is safe to
+// call while subscriptions are running. Feeds stay in the stats after their subscription has ended.
+func (c *Client) Stats() Stats {
+	return c.stats.snapshot()
+}
+
+// LastEventId returns the ID of the last event delivered from the feed at endpoint, which is where its subscription
+// continues from. Returns false if no event has been delivered from the feed yet. It is safe to call while
+// subscriptions are running.
+func (c *Client) LastEventId(endpoint string) (string, bool) {
+	c.stats.mu.Lock()
+	defer c.stats.mu.Unlock()
+
+	feed, ok := c.stats.total.Feeds[endpoint]
+	if !ok || feed.LastEventId == "" {
+		return "", false
+	}
+
+	return feed.LastEventId, true
+}
@@ -0,0 +1,96 @@
+package pkg
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_Stats(t *testing.T) {
+	// 1. Setup a test server that returns two events and fails afterwards
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("lastEventId") == "" {
+			_, _ = w.Write([]byte(`[{"id":"1"},{"id":"2"}]`))
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{
+		PollDelay: 10 * time.Millisecond,
+	})
+	assert.Empty(t, client.Stats().Feeds)
+
+	events := make(chan Event)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		_ = client.Subscribe(ts.URL, "", events, ctx)
+	}()
+
+	<-events
+	<-events
+
+	// 2. Expect the delivered events and the failed polls afterwards
+	assert.Eventually(t, func() bool {
+		return client.Stats().Feeds[ts.URL].ConsecutiveErrors >= 2
+	}, time.Second, 10*time.Millisecond)
+	cancel()
+
+	stats := client.Stats()
+	feed := stats.Feeds[ts.URL]
+	assert.Equal(t, int64(2), stats.EventsDelivered)
+	assert.Equal(t, int64(2), feed.EventsDelivered)
+	assert.Equal(t, "2", feed.LastEventId)
+	assert.False(t, feed.LastSuccessfulPoll.IsZero())
+	assert.True(t, feed.LastPoll.After(feed.LastSuccessfulPoll))
+	assert.Equal(t, stats.LastPoll, feed.LastPoll)
+}
+
+func TestClient_OnAdvance(t *testing.T) {
+	// 1. Setup a test server that returns two events
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("lastEventId") == "" {
+			_, _ = w.Write([]byte(`[{"id":"1"},{"id":"2"}]`))
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer ts.Close()
+
+	advanced := make(chan string, 10)
+	client := NewClient(ClientOptions{
+		PollDelay: 10 * time.Millisecond,
+		OnAdvance: func(endpoint, lastEventId string) {
+			assert.Equal(t, ts.URL, endpoint)
+			advanced <- lastEventId
+		},
+	})
+
+	_, ok := client.LastEventId(ts.URL)
+	assert.False(t, ok)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := make(chan Event)
+	go func() {
+		_ = client.Subscribe(ts.URL, "", events, ctx)
+	}()
+
+	// 2. Expect a call after every delivered event
+	<-events
+	assert.Equal(t, "1", <-advanced)
+	<-events
+	assert.Equal(t, "2", <-advanced)
+
+	lastEventId, ok := client.LastEventId(ts.URL)
+	assert.True(t, ok)
+	assert.Equal(t, "2", lastEventId)
+}
@@ -0,0 +1,200 @@
+package pkg
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// InMemoryStore is an EventStore that keeps events in memory. It is safe for concurrent use by publishers and
+// FeedHandlers and is meant for tests and small deployments.
+type InMemoryStore struct {
+	mu        sync.RWMutex
+	entries   []storeEntry
+	index     map[string]uint64
+	seq       uint64
+	lastID    uint64
+	maxEvents int
+	maxAge    time.Duration
+	notify    chan struct{}
+}
+
+type InMemoryStoreOptions struct {
+	// MaxEvents is the maximum number of retained events. When exceeded, the oldest events are evicted.
+	// Zero means unlimited.
+	MaxEvents int
+
+	// MaxAge is the maximum time an event is retained after it has been appended. Zero means unlimited.
+	MaxAge time.Duration
+}
+
+type storeEntry struct {
+	seq   uint64
+	added time.Time
+	event Event
+}
+
+// NewInMemoryStore creates a new, empty InMemoryStore.
+func NewInMemoryStore(opts InMemoryStoreOptions) *InMemoryStore {
+	return &InMemoryStore{
+		index:     make(map[string]uint64),
+		maxEvents: opts.MaxEvents,
+		maxAge:    opts.MaxAge,
+		notify:    make(chan struct{}),
+	}
+}
+
+// Append adds events to the end of the feed. Events without an ID are assigned a monotonically increasing one,
+// skipping the IDs of retained events.
+func (s *InMemoryStore) Append(events ...Event) {
+	if len(events) == 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.append(events...)
+}
+
+// AppendIdempotent adds an event to the end of the feed unless an event with the same ID is already retained, and
+// reports whether it has been added. Use it for publishing from producers which retry. The event must have an ID,
+// otherwise an error wrapping ErrInvalidEvent is returned. Duplicates of evicted events can't be detected.
+func (s *InMemoryStore) AppendIdempotent(event Event) (bool, error) {
+	if event.ID == "" {
+		return false, fmt.Errorf("%w: an ID is required for appending idempotently", ErrInvalidEvent)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.evict(time.Now())
+	if _, ok := s.index[event.ID]; ok {
+		return false, nil
+	}
+
+	s.append(event)
+	return true, nil
+}
+
+// append adds events and wakes up the waiting requests. Must be called with the lock held.
+func (s *InMemoryStore) append(events ...Event) {
+	now := time.Now()
+	for _, e := range events {
+		s.seq++
+		if e.ID == "" {
+			e.ID = s.nextID()
+		}
+
+		s.entries = append(s.entries, storeEntry{seq: s.seq, added: now, event: e})
+		s.index[e.ID] = s.seq
+	}
+
+	s.evict(now)
+
+	// Wake up waiting long-polling requests
+	close(s.notify)
+	s.notify = make(chan struct{})
+}
+
+// nextID returns the next generated ID which isn't used by a retained event. Must be called with the lock held.
+func (s *InMemoryStore) nextID() string {
+	for {
+		s.lastID++
+		id := strconv.FormatUint(s.lastID, 10)
+		if _, ok := s.index[id]; !ok {
+			return id
+		}
+	}
+}
+
+// After returns up to limit events following the event with the given ID. A limit of zero or less returns all
+// following events. Returns ErrEventNotFound if lastEventId is unknown, e.g. because the event has been evicted.
+func (s *InMemoryStore) After(lastEventId string, limit int) ([]Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.evict(time.Now())
+
+	if len(s.entries) == 0 && lastEventId == "" {
+		return nil, nil
+	}
+
+	start := 0
+	if lastEventId != "" {
+		seq, ok := s.index[lastEventId]
+		if !ok {
+			return nil, ErrEventNotFound
+		}
+		start = int(seq-s.entries[0].seq) + 1
+	}
+
+	end := len(s.entries)
+	if limit > 0 && start+limit < end {
+		end = start + limit
+	}
+	if start >= end {
+		return nil, nil
+	}
+
+	events := make([]Event, 0, end-start)
+	for _, entry := range s.entries[start:end] {
+		events = append(events, entry.event)
+	}
+
+	return events, nil
+}
+
+// Len returns the number of retained events.
+func (s *InMemoryStore) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.entries)
+}
+
+// LatestEventId implements LatestEventStore.
+func (s *InMemoryStore) LatestEventId() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.evict(time.Now())
+	if len(s.entries) == 0 {
+		return "", nil
+	}
+
+	return s.entries[len(s.entries)-1].event.ID, nil
+}
+
+// Notify implements EventNotifier.
+func (s *InMemoryStore) Notify() <-chan struct{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.notify
+}
+
+// evict removes events exceeding MaxEvents or MaxAge. Must be called with the lock held.
+func (s *InMemoryStore) evict(now time.Time) {
+	n := 0
+	if s.maxEvents > 0 && len(s.entries) > s.maxEvents {
+		n = len(s.entries) - s.maxEvents
+	}
+	if s.maxAge > 0 {
+		for n < len(s.entries) && now.Sub(s.entries[n].added) > s.maxAge {
+			n++
+		}
+	}
+	if n == 0 {
+		return
+	}
+
+	// Keep the index of a retained event with the same ID
+	for _, entry := range s.entries[:n] {
+		if s.index[entry.event.ID] == entry.seq {
+			delete(s.index, entry.event.ID)
+		}
+	}
+	s.entries = append([]storeEntry(nil), s.entries[n:]...)
+}
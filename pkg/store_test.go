@@ -0,0 +1,207 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemoryStore_After(t *testing.T) {
+	store := NewInMemoryStore(InMemoryStoreOptions{})
+	store.Append(Event{Subject: "a"}, Event{Subject: "b"}, Event{ID: "custom", Subject: "c"})
+
+	// 1. Expect IDs to be assigned to events without one
+	events, err := store.After("", 0)
+	assert.NoError(t, err)
+	assert.Len(t, events, 3)
+	assert.Equal(t, "1", events[0].ID)
+	assert.Equal(t, "2", events[1].ID)
+	assert.Equal(t, "custom", events[2].ID)
+
+	// 2. Expect the limit and lastEventId to be respected
+	events, err = store.After("1", 1)
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, "2", events[0].ID)
+
+	events, err = store.After("custom", 10)
+	assert.NoError(t, err)
+	assert.Len(t, events, 0)
+
+	// 3. Expect an error for unknown events
+	_, err = store.After("unknown", 10)
+	assert.True(t, errors.Is(err, ErrEventNotFound))
+}
+
+func TestInMemoryStore_MaxEvents(t *testing.T) {
+	store := NewInMemoryStore(InMemoryStoreOptions{MaxEvents: 2})
+	store.Append(Event{}, Event{}, Event{})
+
+	assert.Equal(t, 2, store.Len())
+
+	events, err := store.After("", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "2", events[0].ID)
+	assert.Equal(t, "3", events[1].ID)
+
+	_, err = store.After("1", 0)
+	assert.True(t, errors.Is(err, ErrEventNotFound))
+}
+
+func TestInMemoryStore_generatedIDs(t *testing.T) {
+	store := NewInMemoryStore(InMemoryStoreOptions{})
+	store.Append(Event{ID: "2"})
+	store.Append(Event{}, Event{})
+
+	// Expect the generated IDs to skip the ID of the retained event
+	events, err := store.After("2", 0)
+	assert.NoError(t, err)
+	if assert.Len(t, events, 2) {
+		assert.Equal(t, "1", events[0].ID)
+		assert.Equal(t, "3", events[1].ID)
+	}
+}
+
+func TestInMemoryStore_MaxEventsDuplicateID(t *testing.T) {
+	store := NewInMemoryStore(InMemoryStoreOptions{MaxEvents: 2})
+	store.Append(Event{ID: "2"}, Event{ID: "2"}, Event{ID: "3"})
+
+	// Expect the eviction of the first event to keep the index of the retained one with the same ID
+	events, err := store.After("2", 0)
+	assert.NoError(t, err)
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, "3", events[0].ID)
+	}
+}
+
+func TestInMemoryStore_MaxAge(t *testing.T) {
+	store := NewInMemoryStore(InMemoryStoreOptions{MaxAge: 20 * time.Millisecond})
+	store.Append(Event{})
+	time.Sleep(30 * time.Millisecond)
+	store.Append(Event{})
+
+	events, err := store.After("", 0)
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, "2", events[0].ID)
+}
+
+func TestInMemoryStore_concurrentAppend(t *testing.T) {
+	store := NewInMemoryStore(InMemoryStoreOptions{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				store.Append(Event{})
+				_, _ = store.After("", 10)
+			}
+		}()
+	}
+	wg.Wait()
+
+	events, err := store.After("", 0)
+	assert.NoError(t, err)
+	assert.Len(t, events, 1000)
+	for i, e := range events {
+		assert.Equal(t, strconv.Itoa(i+1), e.ID)
+	}
+}
+
+func TestInMemoryStore_AppendIdempotent(t *testing.T) {
+	store := NewInMemoryStore(InMemoryStoreOptions{})
+
+	// 1. Expect concurrent retries of the same publish to add the event once
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	added := 0
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ok, err := store.AppendIdempotent(Event{ID: "order-1"})
+			assert.NoError(t, err)
+			if ok {
+				mu.Lock()
+				added++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, added)
+	assert.Equal(t, 1, store.Len())
+
+	ok, err := store.AppendIdempotent(Event{ID: "order-2"})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 2, store.Len())
+
+	// 2. Expect an error for events without an ID
+	_, err = store.AppendIdempotent(Event{})
+	assert.ErrorIs(t, err, ErrInvalidEvent)
+}
+
+func TestInMemoryStore_FeedHandler(t *testing.T) {
+	store := NewInMemoryStore(InMemoryStoreOptions{})
+	ts := httptest.NewServer(NewFeedHandler(store, FeedHandlerOptions{}))
+	defer ts.Close()
+
+	events := make(chan Event)
+	client := NewClient(ClientOptions{
+		PollDelay: 10 * time.Millisecond,
+		Timeout:   500 * time.Millisecond,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	go func() {
+		_ = client.Subscribe(ts.URL, "", events, ctx)
+	}()
+
+	// 1. Expect appended events to be pushed to the long-polling client
+	time.Sleep(50 * time.Millisecond)
+	store.Append(Event{Subject: "a"})
+
+	ev1 := <-events
+	assert.Equal(t, "1", ev1.ID)
+
+	store.Append(Event{Subject: "b"})
+
+	ev2 := <-events
+	assert.Equal(t, "2", ev2.ID)
+}
+
+func TestInMemoryStore_LatestEventId(t *testing.T) {
+	store := NewInMemoryStore(InMemoryStoreOptions{})
+	ts := httptest.NewServer(NewFeedHandler(store, FeedHandlerOptions{BatchSize: 1}))
+	defer ts.Close()
+
+	// 1. Expect no header for an empty feed
+	resp, err := http.Get(ts.URL)
+	assert.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Empty(t, resp.Header.Get(LatestEventIdHeader))
+
+	// 2. Expect the latest event to be reported, also on the pages before it
+	store.Append(Event{}, Event{}, Event{})
+	latest, err := store.LatestEventId()
+	assert.NoError(t, err)
+	assert.Equal(t, "3", latest)
+
+	resp, err = http.Get(ts.URL)
+	assert.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, "3", resp.Header.Get(LatestEventIdHeader))
+}
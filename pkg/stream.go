@@ -0,0 +1,47 @@
+package pkg
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// decodeStream decodes successive JSON documents pushed in one response and passes the events to handle as they
+// arrive. Every document is either a single event or a JSON array of events, null is ignored. Returns when the
+// response ends.
+func decodeStream(body io.Reader, opts decodeOptions, handle func(Event) error) (int, error) {
+	decoder := json.NewDecoder(body)
+
+	n := 0
+	for {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				return n, nil
+			}
+			return n, fmt.Errorf("could not decode pushed document: %w", err)
+		}
+		if bytes.Equal(raw, []byte("null")) {
+			continue
+		}
+
+		events, err := decodeMessage(raw, opts)
+		if err != nil {
+			err = fmt.Errorf("could not decode pushed document: %w", err)
+			if opts.skip == nil {
+				return n, err
+			}
+			opts.skip(fmt.Errorf("skipping malformed event: %w", err))
+			continue
+		}
+
+		for _, e := range events {
+			if err := handle(e); err != nil {
+				return n, err
+			}
+			n++
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeStream(t *testing.T) {
+	body := `[{"id":"1"},{"id":"2"}]` + "\n" + `null {"id":"3"}[]` + "\n" + `[{"id":"4"}]`
+
+	var ids []string
+	n, err := decodeStream(strings.NewReader(body), decodeOptions{decoder: DefaultDecoder}, func(e Event) error {
+		ids = append(ids, e.ID)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, n)
+	assert.Equal(t, []string{"1", "2", "3", "4"}, ids)
+}
+
+func TestClient_Subscribe_Stream(t *testing.T) {
+	release := make(chan struct{})
+
+	// 1. Setup a test server that keeps the response open and pushes a batch at a time
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "", r.URL.Query().Get("timeout"))
+		switch r.URL.Query().Get("lastEventId") {
+		case "":
+			fmt.Fprintln(w, `[{"id":"1"}]`)
+			w.(http.Flusher).Flush()
+			<-release
+			fmt.Fprintln(w, `[{"id":"2"}]`)
+
+		// 2. The reconnect after the end of the response resumes from the last received event
+		case "2":
+			fmt.Fprintln(w, `[{"id":"3"}]`)
+		}
+	}))
+	defer ts.Close()
+
+	events := make(chan Event)
+	client := NewClient(ClientOptions{
+		PollDelay:      10 * time.Millisecond,
+		Timeout:        50 * time.Millisecond,
+		RequestTimeout: 100 * time.Millisecond,
+		Transport:      TransportStream,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	go func() {
+		_ = client.Subscribe(ts.URL, "", events, ctx)
+	}()
+
+	// 3. Expect the events as they are pushed, also beyond the RequestTimeout
+	assert.Equal(t, "1", (<-events).ID)
+	time.Sleep(150 * time.Millisecond)
+	close(release)
+	assert.Equal(t, "2", (<-events).ID)
+	assert.Equal(t, "3", (<-events).ID)
+}
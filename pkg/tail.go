@@ -0,0 +1,85 @@
+package pkg
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+)
+
+const DefaultTailSize = 100
+
+// TailCache persists the most recent events of a subscription, so that they can be served right away after a restart
+// while the subscription catches up with the feed, e.g. for dashboards.
+type TailCache interface {
+	// Load returns the stored events, oldest first, or nil if none have been stored yet.
+	Load() ([]Event, error)
+
+	// Save stores the most recent events, oldest first.
+	Save(events []Event) error
+}
+
+// FileTailCache is a TailCache that stores the events as JSON in a file.
+type FileTailCache struct {
+	path string
+}
+
+// NewFileTailCache creates a FileTailCache that stores the events at path. The file is created on the first Save.
+func NewFileTailCache(path string) *FileTailCache {
+	return &FileTailCache{path: path}
+}
+
+// Load reads the events from the file. A missing file is not an error and yields no events.
+func (c *FileTailCache) Load() ([]Event, error) {
+	b, err := os.ReadFile(c.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	if err := json.Unmarshal(b, &events); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// Save writes the events to the file. The file is replaced atomically, like the file of a FileCursor.
+func (c *FileTailCache) Save(events []Event) error {
+	b, err := json.Marshal(events)
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(c.path, b)
+}
+
+// tail keeps the most recent events of a subscription for its TailCache.
+type tail struct {
+	cache   TailCache
+	size    int
+	events  []Event
+	changed bool
+}
+
+// add appends delivered events, dropping the oldest ones beyond the size of the tail.
+func (t *tail) add(events []Event) {
+	t.events = append(t.events, events...)
+	if len(t.events) > t.size {
+		t.events = append([]Event(nil), t.events[len(t.events)-t.size:]...)
+	}
+	t.changed = true
+}
+
+// save stores the tail if events have been added since it was stored last.
+func (t *tail) save() error {
+	if !t.changed {
+		return nil
+	}
+
+	t.changed = false
+	return t.cache.Save(t.events)
+}
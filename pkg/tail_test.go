@@ -0,0 +1,90 @@
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileTailCache(t *testing.T) {
+	cache := NewFileTailCache(filepath.Join(t.TempDir(), "tail"))
+
+	// 1. Loading a missing file yields no events
+	events, err := cache.Load()
+	assert.NoError(t, err)
+	assert.Nil(t, events)
+
+	// 2. Saved events are loaded again
+	assert.NoError(t, cache.Save([]Event{{ID: "1", Extensions: map[string]interface{}{"tenant": "a"}}, {ID: "2"}}))
+
+	events, err = cache.Load()
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, "a", events[0].Extensions["tenant"])
+	assert.Equal(t, "2", events[1].ID)
+}
+
+func TestTail_add(t *testing.T) {
+	tail := &tail{size: 2}
+	tail.add([]Event{{ID: "1"}})
+	tail.add([]Event{{ID: "2"}, {ID: "3"}})
+
+	assert.Equal(t, []Event{{ID: "2"}, {ID: "3"}}, tail.events)
+	assert.True(t, tail.changed)
+}
+
+func TestClient_Subscribe_TailCache(t *testing.T) {
+	// 1. Setup a test server with two events, and a third one after the restart
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("lastEventId") {
+		case "":
+			fmt.Fprintln(w, `[{"id":"1"},{"id":"2"}]`)
+		case "2":
+			fmt.Fprintln(w, `[{"id":"3"}]`)
+		default:
+			fmt.Fprintln(w, `[]`)
+		}
+	}))
+	defer ts.Close()
+
+	dir := t.TempDir()
+	opts := ClientOptions{
+		PollDelay: 10 * time.Millisecond,
+		Cursor:    NewFileCursor(filepath.Join(dir, "cursor")),
+		TailCache: NewFileTailCache(filepath.Join(dir, "tail")),
+		MaxEvents: 2,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	err := NewClient(opts).SubscribeFunc(ts.URL, "", func(e Event) error {
+		assert.False(t, e.Cached)
+		return nil
+	}, ctx)
+	assert.NoError(t, err)
+
+	// 2. Expect the cached events to be delivered again after the restart, followed by the new event
+	var events []Event
+	opts.MaxEvents = 1
+	err = NewClient(opts).SubscribeFunc(ts.URL, "", func(e Event) error {
+		events = append(events, e)
+		return nil
+	}, ctx)
+	assert.NoError(t, err)
+
+	assert.Len(t, events, 3)
+	assert.Equal(t, "1", events[0].ID)
+	assert.True(t, events[0].Cached)
+	assert.Equal(t, ts.URL, events[0].Endpoint)
+	assert.Equal(t, "2", events[1].ID)
+	assert.True(t, events[1].Cached)
+	assert.Equal(t, "3", events[2].ID)
+	assert.False(t, events[2].Cached)
+}
@@ -0,0 +1,55 @@
+// Package testutil provides helpers for testing consumers of HTTP feeds.
+package testutil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/korve/go-http-feeds/pkg"
+)
+
+// FeedServer is an HTTP feed server for tests. It serves its events with simple polling and long-polling like a real
+// feed server, and events can be appended while it is running. The URL of the feed is the URL of the server.
+type FeedServer struct {
+	*httptest.Server
+	store    *pkg.InMemoryStore
+	requests atomic.Int64
+}
+
+type FeedServerOptions struct {
+	// BatchSize is the maximum number of events returned per response. Defaults to pkg.DefaultBatchSize.
+	BatchSize int
+}
+
+// NewFeedServer starts a FeedServer serving events. Events without an ID are assigned one. The server is closed when
+// the test ends.
+func NewFeedServer(t testing.TB, events []pkg.Event, opts FeedServerOptions) *FeedServer {
+	s := &FeedServer{store: pkg.NewInMemoryStore(pkg.InMemoryStoreOptions{})}
+	s.store.Append(events...)
+
+	handler := pkg.NewFeedHandler(s.store, pkg.FeedHandlerOptions{BatchSize: opts.BatchSize})
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.requests.Add(1)
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// Append adds events to the end of the feed and wakes up waiting long-polling requests.
+func (s *FeedServer) Append(events ...pkg.Event) {
+	s.store.Append(events...)
+}
+
+// Len returns the number of events in the feed.
+func (s *FeedServer) Len() int {
+	return s.store.Len()
+}
+
+// Requests returns the number of requests the server has received.
+func (s *FeedServer) Requests() int {
+	return int(s.requests.Load())
+}
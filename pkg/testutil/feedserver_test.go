@@ -0,0 +1,63 @@
+package testutil
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/korve/go-http-feeds/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeedServer_SimplePolling(t *testing.T) {
+	// 1. Set up a feed server with two events, returned one at a time
+	ts := NewFeedServer(t, []pkg.Event{{Type: "a"}, {Type: "b"}}, FeedServerOptions{BatchSize: 1})
+
+	client := pkg.NewClient(pkg.ClientOptions{PollDelay: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	events := make(chan pkg.Event)
+	go func() {
+		_ = client.Subscribe(ts.URL, "", events, ctx)
+	}()
+
+	// 2. Expect the events in order with their assigned IDs
+	e := <-events
+	assert.Equal(t, "1", e.ID)
+	assert.Equal(t, "a", e.Type)
+	e = <-events
+	assert.Equal(t, "2", e.ID)
+	assert.Equal(t, "b", e.Type)
+	assert.GreaterOrEqual(t, ts.Requests(), 2)
+}
+
+func TestFeedServer_LongPolling(t *testing.T) {
+	// 1. Set up an empty feed server
+	ts := NewFeedServer(t, nil, FeedServerOptions{})
+
+	client := pkg.NewClient(pkg.ClientOptions{Timeout: 1 * time.Second})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	events := make(chan pkg.Event)
+	go func() {
+		_ = client.Subscribe(ts.URL, "", events, ctx)
+	}()
+
+	// 2. Expect an appended event to be delivered to the waiting request
+	assert.Eventually(t, func() bool {
+		return ts.Requests() == 1
+	}, time.Second, 5*time.Millisecond)
+	ts.Append(pkg.Event{ID: "x"})
+
+	select {
+	case e := <-events:
+		assert.Equal(t, "x", e.ID)
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("event was not delivered while long-polling")
+	}
+	assert.Equal(t, 1, ts.Len())
+}
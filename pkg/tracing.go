@@ -0,0 +1,57 @@
+package pkg
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the name of the OpenTelemetry tracer used by the client.
+const tracerName = "github.com/korve/go-http-feeds"
+
+// Span attributes recorded by the client.
+const (
+	attributeEndpoint    = attribute.Key("feed.endpoint")
+	attributeLastEventId = attribute.Key("feed.last_event_id")
+	attributeEventCount  = attribute.Key("feed.event_count")
+	attributeStatusCode  = attribute.Key("http.response.status_code")
+	attributeRequestID   = attribute.Key("feed.request_id")
+)
+
+// startPollSpan starts the span of a single poll of a subscription. The span links to the span of the previous poll,
+// so that the catch-up of a subscription can be followed from poll to poll.
+func (c *Client) startPollSpan(endpoint, lastEventId, requestID string, previous trace.SpanContext, ctx context.Context) (context.Context, trace.Span) {
+	opts := []trace.SpanStartOption{
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(
+			attributeEndpoint.String(endpoint),
+			attributeLastEventId.String(lastEventId),
+			attributeRequestID.String(requestID),
+		),
+	}
+	if previous.IsValid() {
+		opts = append(opts, trace.WithLinks(trace.Link{SpanContext: previous}))
+	}
+
+	return c.tracer.Start(ctx, "poll feed", opts...)
+}
+
+// startRequestSpan starts the span of a single request to the feed.
+func (c *Client) startRequestSpan(method, endpoint, lastEventId string, ctx context.Context) (context.Context, trace.Span) {
+	return c.tracer.Start(ctx, method+" feed",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attributeEndpoint.String(endpoint), attributeLastEventId.String(lastEventId)),
+	)
+}
+
+// endSpan records the number of events and the error, if any, and ends the span.
+func endSpan(span trace.Span, n int, err error) {
+	span.SetAttributes(attributeEventCount.Int(n))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
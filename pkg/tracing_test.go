@@ -0,0 +1,96 @@
+package pkg
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestClient_Subscribe_Tracing(t *testing.T) {
+	// 1. Setup a test server that returns an event on the first poll
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("lastEventId") == "" {
+			_, _ = w.Write([]byte(`[{"id":"1"}]`))
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer ts.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	client := NewClient(ClientOptions{
+		PollDelay:      10 * time.Millisecond,
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)),
+	})
+
+	events := make(chan Event)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		_ = client.Subscribe(ts.URL, "", events, ctx)
+	}()
+
+	// 2. Wait for the event and the following poll
+	<-events
+	assert.Eventually(t, func() bool {
+		return len(recorder.Ended()) >= 4
+	}, time.Second, 10*time.Millisecond)
+	cancel()
+
+	// 3. Expect a request span within each poll span and a link to the previous poll
+	spans := recorder.Ended()
+	request, firstPoll, secondPoll := spans[0], spans[1], spans[3]
+	assert.Equal(t, "GET feed", request.Name())
+	assert.Equal(t, "poll feed", firstPoll.Name())
+	assert.Equal(t, firstPoll.SpanContext().SpanID(), request.Parent().SpanID())
+	assert.Contains(t, request.Attributes(), attributeStatusCode.Int(http.StatusOK))
+	assert.Contains(t, firstPoll.Attributes(), attributeEventCount.Int(1))
+	assert.Contains(t, secondPoll.Attributes(), attributeLastEventId.String("1"))
+	assert.Len(t, secondPoll.Links(), 1)
+	assert.Equal(t, firstPoll.SpanContext(), secondPoll.Links()[0].SpanContext)
+}
+
+func TestClient_Subscribe_TracingRedactsCredentials(t *testing.T) {
+	// 1. Setup a test server
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer ts.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	client := NewClient(ClientOptions{
+		PollDelay:      10 * time.Millisecond,
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)),
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	endpoint := strings.Replace(ts.URL, "http://", "http://user:secret@", 1)
+	go func() {
+		_ = client.Subscribe(endpoint, "", make(chan Event), ctx)
+	}()
+
+	// 2. Expect the password to be redacted from the endpoint of the poll and request spans
+	assert.Eventually(t, func() bool {
+		return len(recorder.Ended()) >= 2
+	}, time.Second, 10*time.Millisecond)
+	cancel()
+
+	for _, span := range recorder.Ended()[:2] {
+		for _, attr := range span.Attributes() {
+			if attr.Key == attributeEndpoint {
+				assert.NotContains(t, attr.Value.AsString(), "secret", span.Name())
+				assert.Contains(t, attr.Value.AsString(), "user:xxxxx@", span.Name())
+			}
+		}
+	}
+}
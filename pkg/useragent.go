@@ -0,0 +1,29 @@
+package pkg
+
+import "runtime/debug"
+
+// modulePath is the path of the module of this package.
+const modulePath = "github.com/korve/go-http-feeds"
+
+// DefaultUserAgent is the User-Agent sent by clients without ClientOptions.UserAgent. It contains the version of
+// the module if it is known from the build info.
+var DefaultUserAgent = "go-http-feeds/" + moduleVersion()
+
+// moduleVersion returns the version of this module in the build, or "devel" if it is unknown.
+func moduleVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "devel"
+	}
+
+	if info.Main.Path == modulePath && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == modulePath {
+			return dep.Version
+		}
+	}
+
+	return "devel"
+}
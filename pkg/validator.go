@@ -0,0 +1,54 @@
+package pkg
+
+import (
+	"net/url"
+	"sync"
+)
+
+// validatorCache remembers a validator of the last response of each feed, like the ETag or the Last-Modified date,
+// for sending conditional requests. Only the most recent request URL of a feed is remembered, as the URL changes with
+// the last event ID once new events arrive. It is shared by all subscriptions of a Client.
+type validatorCache struct {
+	mu    sync.Mutex
+	feeds map[string]validatorEntry
+}
+
+type validatorEntry struct {
+	url   string
+	value string
+}
+
+func newValidatorCache() *validatorCache {
+	return &validatorCache{feeds: map[string]validatorEntry{}}
+}
+
+// feedKey identifies the feed of a request URL, ignoring the query.
+func feedKey(u *url.URL) string {
+	return u.Scheme + "://" + u.Host + u.Path
+}
+
+// get returns the validator of the last response for u, or an empty string if u wasn't requested last.
+func (c *validatorCache) get(u *url.URL) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.feeds[feedKey(u)]
+	if !ok || entry.url != u.String() {
+		return ""
+	}
+
+	return entry.value
+}
+
+// set remembers the validator of the response for u. An empty value forgets the feed.
+func (c *validatorCache) set(u *url.URL, value string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if value == "" {
+		delete(c.feeds, feedKey(u))
+		return
+	}
+
+	c.feeds[feedKey(u)] = validatorEntry{url: u.String(), value: value}
+}
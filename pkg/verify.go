@@ -0,0 +1,64 @@
+package pkg
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// SignatureHeader is the default header carrying the signature verified by an HMACVerifier.
+const SignatureHeader = "Signature"
+
+// ErrInvalidSignature is returned when the signature of a response can't be verified.
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// Verifier checks the authenticity of feed responses before their events are decoded, e.g. for feeds crossing trust
+// boundaries. Responses failing the verification are rejected as a whole. Implementations must be safe for
+// concurrent use, as they are shared by all subscriptions of a Client.
+type Verifier interface {
+	// Verify returns an error if body, the complete decompressed response body, isn't authentic according to the
+	// response header. The error should wrap ErrInvalidSignature.
+	Verify(body []byte, header http.Header) error
+}
+
+// HMACVerifier is a Verifier for responses signed with HMAC-SHA256 and a shared secret. The hex encoded signature of
+// the response body is expected in a header, optionally prefixed with "sha256=".
+type HMACVerifier struct {
+	secret []byte
+	header string
+}
+
+// NewHMACVerifier creates an HMACVerifier with the shared secret, which expects the signature in header. An empty
+// header defaults to SignatureHeader.
+func NewHMACVerifier(secret []byte, header string) *HMACVerifier {
+	if header == "" {
+		header = SignatureHeader
+	}
+
+	return &HMACVerifier{secret: secret, header: header}
+}
+
+// Verify implements Verifier.
+func (v *HMACVerifier) Verify(body []byte, header http.Header) error {
+	value := header.Get(v.header)
+	if value == "" {
+		return fmt.Errorf("%w: missing %s header", ErrInvalidSignature, v.header)
+	}
+
+	signature, err := hex.DecodeString(strings.TrimPrefix(value, "sha256="))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+
+	mac := hmac.New(sha256.New, v.secret)
+	mac.Write(body)
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return fmt.Errorf("%w: signature doesn't match the response", ErrInvalidSignature)
+	}
+
+	return nil
+}
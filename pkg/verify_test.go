@@ -0,0 +1,73 @@
+package pkg
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sign(secret []byte, body string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(body))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestHMACVerifier(t *testing.T) {
+	secret := []byte("secret")
+	body := `[{"id":"1"}]`
+	verifier := NewHMACVerifier(secret, "")
+
+	header := http.Header{}
+	header.Set(SignatureHeader, sign(secret, body))
+	assert.NoError(t, verifier.Verify([]byte(body), header))
+
+	header.Set(SignatureHeader, "sha256="+sign(secret, body))
+	assert.NoError(t, verifier.Verify([]byte(body), header))
+
+	// Expect tampered bodies, other secrets and missing signatures to be rejected
+	assert.ErrorIs(t, verifier.Verify([]byte(`[{"id":"2"}]`), header), ErrInvalidSignature)
+	header.Set(SignatureHeader, sign([]byte("other"), body))
+	assert.ErrorIs(t, verifier.Verify([]byte(body), header), ErrInvalidSignature)
+	header.Set(SignatureHeader, "not hex")
+	assert.ErrorIs(t, verifier.Verify([]byte(body), header), ErrInvalidSignature)
+	assert.ErrorIs(t, verifier.Verify([]byte(body), http.Header{}), ErrInvalidSignature)
+}
+
+func TestClient_fetchEvents_Verifier(t *testing.T) {
+	secret := []byte("secret")
+
+	// 1. Set up a test server signing its responses, except for the second page
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := `[{"id":"1"}]`
+		signature := sign(secret, body)
+		if r.URL.Query().Get("lastEventId") == "1" {
+			body = `[{"id":"2"}]`
+		}
+
+		w.Header().Set("X-Feed-Signature", signature)
+		fmt.Fprint(w, body)
+	}))
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{Verifier: NewHMACVerifier(secret, "X-Feed-Signature")})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Expect signed responses to be accepted and others to be rejected as a whole
+	events, err := client.fetchEvents(ts.URL, "", ctx)
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+
+	events, err = client.fetchEvents(ts.URL, "1", ctx)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+	assert.Empty(t, events)
+}
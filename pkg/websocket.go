@@ -0,0 +1,116 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/websocket"
+)
+
+// websocketURL returns the WebSocket URL of u, converting http and https URLs to ws and wss.
+func websocketURL(u *url.URL) *url.URL {
+	ws := *u
+	switch u.Scheme {
+	case "http":
+		ws.Scheme = "ws"
+	case "https":
+		ws.Scheme = "wss"
+	}
+
+	return &ws
+}
+
+// streamWebSocket connects to the WebSocket endpoint u and passes the events of the received messages to handle
+// until the connection is closed. Every message contains an event or a JSON array of events.
+func (c *Client) streamWebSocket(u *url.URL, handle func(Event) error, ctx context.Context) (n int, err error) {
+	ctx, span := c.startRequestSpan(http.MethodGet, u.Redacted(), u.Query().Get(c.cursorParam), ctx)
+	defer func() { endSpan(span, n, err) }()
+
+	if c.rateLimiter != nil {
+		if err := c.rateLimiter.Wait(ctx); err != nil {
+			return 0, err
+		}
+	}
+
+	header := http.Header{}
+	c.addHeaders(header, u, ctx)
+	if lastEventId := u.Query().Get(c.cursorParam); lastEventId != "" {
+		header.Set("Last-Event-ID", lastEventId)
+	}
+
+	// Use the TLS and proxy configuration of the HTTP client
+	dialer := *websocket.DefaultDialer
+	if transport, ok := c.httpClient.Transport.(*http.Transport); ok {
+		dialer.TLSClientConfig = transport.TLSClientConfig
+		dialer.Proxy = transport.Proxy
+	}
+
+	conn, resp, err := dialer.DialContext(ctx, websocketURL(withoutUserinfo(u)).String(), header)
+	if err != nil {
+		if resp != nil && resp.StatusCode != http.StatusSwitchingProtocols {
+			defer drainBody(resp.Body)
+			return 0, responseError(resp, resp.Body, c.clock.Now())
+		}
+		return 0, err
+	}
+	defer conn.Close()
+	if c.maxResponseBytes > 0 {
+		conn.SetReadLimit(c.maxResponseBytes)
+	}
+
+	// Unblock the reading when the subscription is cancelled
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = conn.Close()
+		case <-done:
+		}
+	}()
+
+	opts := c.decodeOptions(ctx)
+
+	for {
+		msgType, data, err := conn.ReadMessage()
+		if err != nil {
+			if ctx.Err() != nil {
+				return n, ctx.Err()
+			}
+			if errors.Is(err, websocket.ErrReadLimit) {
+				return n, fmt.Errorf("%w: message exceeds %d bytes", ErrResponseTooLarge, c.maxResponseBytes)
+			}
+			if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				return n, nil
+			}
+			return n, err
+		}
+		if msgType != websocket.TextMessage && msgType != websocket.BinaryMessage {
+			continue
+		}
+
+		events, err := decodeMessage(data, opts)
+		if err != nil {
+			err = fmt.Errorf("could not decode websocket message: %w", err)
+			if opts.skip == nil {
+				return n, err
+			}
+			opts.skip(fmt.Errorf("skipping malformed event: %w", err))
+			continue
+		}
+
+		for _, e := range events {
+			if err := handle(e); err != nil {
+				// Say goodbye to the server if the connection is still open
+				if ctx.Err() == nil {
+					_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+				}
+				return n, err
+			}
+			n++
+		}
+	}
+}
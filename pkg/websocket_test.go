@@ -0,0 +1,122 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_Subscribe_WebSocket(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+
+	// 1. Setup a test server that pushes events and closes the connection after each batch
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastEventId := r.URL.Query().Get("lastEventId")
+		assert.Equal(t, lastEventId, r.Header.Get("Last-Event-ID"))
+		assert.Equal(t, "", r.URL.Query().Get("timeout"))
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer conn.Close()
+
+		if lastEventId == "" {
+			_ = conn.WriteMessage(websocket.TextMessage, []byte(`{"id":"1"}`))
+			_ = conn.WriteMessage(websocket.TextMessage, []byte(`[{"id":"2"},{"id":"3"}]`))
+		} else {
+			// 2. The reconnect resumes from the last received event
+			assert.Equal(t, "3", lastEventId)
+			_ = conn.WriteMessage(websocket.TextMessage, []byte(`{"id":"4"}`))
+		}
+		_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	}))
+	defer ts.Close()
+
+	events := make(chan Event)
+	client := NewClient(ClientOptions{
+		PollDelay: 10 * time.Millisecond,
+		Timeout:   100 * time.Millisecond,
+		Transport: TransportWebSocket,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	go func() {
+		_ = client.Subscribe(ts.URL, "", events, ctx)
+	}()
+
+	// 3. Expect the events of both connections
+	for _, id := range []string{"1", "2", "3", "4"} {
+		e := <-events
+		assert.Equal(t, id, e.ID)
+		assert.Equal(t, ts.URL, e.Endpoint)
+	}
+}
+
+func TestClient_Subscribe_WebSocketCancel(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+
+	// 1. Setup a test server that keeps the connection open without sending events
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_, _, _ = conn.ReadMessage()
+	}))
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{Transport: TransportWebSocket})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// 2. Expect the subscription to end with the context
+	err := client.Subscribe(ts.URL, "", make(chan Event), ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestClient_streamEvents_WebSocketMaxResponseBytes(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+
+	// 1. Setup a test server that pushes a message beyond the limit
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer conn.Close()
+
+		_ = conn.WriteMessage(websocket.TextMessage, []byte(`{"id":"1"}`))
+		_ = conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf(`{"id":"2","subject":"%s"}`, strings.Repeat("x", 1000))))
+	}))
+	defer ts.Close()
+
+	client := NewClient(ClientOptions{
+		Transport:        TransportWebSocket,
+		MaxResponseBytes: 100,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+
+	// 2. Expect the connection to fail with the message beyond the limit, after the events before it
+	var ids []string
+	_, err := client.streamEvents(ts.URL, "", func(e Event) error {
+		ids = append(ids, e.ID)
+		return nil
+	}, ctx)
+	assert.True(t, errors.Is(err, ErrResponseTooLarge), err)
+	assert.Equal(t, []string{"1"}, ids)
+}